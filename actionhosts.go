@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// actionHostAllowed reports whether host matches one of the allowed host
+// suffixes. A suffix matches the host itself and any of its subdomains, so
+// "example.com" allows "ci.example.com" but not "badexample.com". An empty
+// allowlist allows every host.
+func actionHostAllowed(host string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, suffix := range allowed {
+		suffix = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(suffix), "."))
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateActionHost checks that an action URL's host is allowed.
+func validateActionHost(rawURL string, allowed []string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if !actionHostAllowed(parsed.Hostname(), allowed) {
+		return fmt.Errorf("URL host %q is not in allowed_action_hosts", parsed.Hostname())
+	}
+	return nil
+}
+
+// validateAllowedActionHosts reports allowlist entries that aren't bare host names.
+func validateAllowedActionHosts(vb *helpers.ValidationBuilder, allowed []string) {
+	for _, host := range allowed {
+		host = strings.TrimSpace(host)
+		if host == "" || strings.ContainsAny(host, "/:@ ") {
+			vb.AddErrorWithCode("allowed_action_hosts",
+				fmt.Sprintf("allowed_action_hosts entry %q must be a host name such as example.com", host), "format")
+		}
+	}
+}
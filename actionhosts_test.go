@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestActionHostAllowed(t *testing.T) {
+	t.Parallel()
+
+	allowed := []string{"github.com", ".ci.example.com"}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "github.com", want: true},
+		{host: "GitHub.com", want: true},
+		{host: "api.github.com", want: true},
+		{host: "ci.example.com", want: true},
+		{host: "build.ci.example.com", want: true},
+		{host: "example.com", want: false},
+		{host: "evilgithub.com", want: false},
+		{host: "github.com.evil.io", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := actionHostAllowed(tt.host, allowed); got != tt.want {
+			t.Errorf("actionHostAllowed(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+	if !actionHostAllowed("anything.example", nil) {
+		t.Error("expected an empty allowlist to allow any host")
+	}
+}
+
+func TestValidateAllowedActionHosts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantValid bool
+		wantField string
+	}{
+		{
+			name: "no_allowlist",
+			config: map[string]any{
+				"release_notes_url": "https://docs.anywhere.io/notes",
+			},
+			wantValid: true,
+		},
+		{
+			name: "allowed_hosts",
+			config: map[string]any{
+				"allowed_action_hosts": []any{"github.com", "ci.example.com"},
+				"release_notes_url":    "https://github.com/owner/repo/releases",
+				"logs_url_template":    "https://build.ci.example.com/{{repository}}/{{commit}}",
+			},
+			wantValid: true,
+		},
+		{
+			name: "disallowed_release_notes_url",
+			config: map[string]any{
+				"allowed_action_hosts": []any{"github.com"},
+				"release_notes_url":    "https://evil.example.net/notes",
+			},
+			wantField: "release_notes_url",
+		},
+		{
+			name: "disallowed_logs_url_template",
+			config: map[string]any{
+				"allowed_action_hosts": []any{"github.com"},
+				"logs_url_template":    "https://notgithub.com/{{commit}}",
+			},
+			wantField: "logs_url_template",
+		},
+		{
+			name: "malformed_entry",
+			config: map[string]any{
+				"allowed_action_hosts": []any{"https://github.com/"},
+			},
+			wantField: "allowed_action_hosts",
+		},
+	}
+
+	p := &TeamsPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Fatalf("expected Valid=%v, got %+v", tt.wantValid, resp.Errors)
+			}
+			if tt.wantField != "" && (len(resp.Errors) == 0 || resp.Errors[0].Field != tt.wantField) {
+				t.Errorf("expected an error for %s, got %+v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestLogsURLRespectsAllowedActionHosts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		env     string
+		wantURL string
+	}{
+		{name: "env_allowed", env: "https://ci.example.com/runs/42", wantURL: "https://ci.example.com/runs/42"},
+		{name: "env_disallowed", env: "https://evil.example.net/runs/42"},
+		{name: "template_disallowed", cfg: Config{LogsURLTemplate: "https://evil.example.net/{{version}}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := tt.cfg
+			cfg.ShowActions = true
+			cfg.AllowedActionHosts = []string{"ci.example.com"}
+			logger := &captureLogger{}
+			n := (&TeamsPlugin{Logger: logger}).buildErrorNotification(&cfg, plugin.ReleaseContext{
+				Version:     "1.0.0",
+				Environment: map[string]string{EnvLogsURL: tt.env},
+			})
+			if tt.wantURL == "" {
+				if len(n.actions) != 0 {
+					t.Errorf("expected the logs action to be dropped, got %+v", n.actions)
+				}
+				if _, ok := logger.find("warn", "ignoring logs URL"); !ok {
+					t.Error("expected a warning")
+				}
+				return
+			}
+			if len(n.actions) != 1 || n.actions[0].URL != tt.wantURL {
+				t.Errorf("expected the logs action for %s, got %+v", tt.wantURL, n.actions)
+			}
+		})
+	}
+}
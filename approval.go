@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Release environment keys carrying approval metadata from the PostApprove hook.
+const (
+	EnvApprovedBy = "RELICTA_APPROVED_BY"
+	EnvApprovedAt = "RELICTA_APPROVED_AT"
+)
+
+// resolveApproval returns the "Approved by" fact value, or "" when no approver
+// is known. Config values take precedence over the release environment.
+func resolveApproval(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	approver := cfg.ApprovedBy
+	if approver == "" {
+		approver = releaseCtx.Environment[EnvApprovedBy]
+	}
+	if approver == "" {
+		return ""
+	}
+
+	approvedAt := cfg.ApprovedAt
+	if approvedAt == "" {
+		approvedAt = releaseCtx.Environment[EnvApprovedAt]
+	}
+	if approvedAt != "" {
+		return fmt.Sprintf("%s (%s)", approver, approvedAt)
+	}
+	return approver
+}
+
+// sendApprovalNotification sends a release approved notification to Teams.
+func (p *TeamsPlugin) sendApprovalNotification(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	return p.dispatch(ctx, cfg, p.buildApprovalNotification(cfg, releaseCtx), dryRun), nil
+}
+
+// buildApprovalNotification builds the release approved card.
+func (p *TeamsPlugin) buildApprovalNotification(cfg *Config, releaseCtx plugin.ReleaseContext) notification {
+	title := fmt.Sprintf("Release %s Approved", releaseCtx.Version)
+	color := resolveCardColor(StatusApproval, releaseCtx, cfg)
+
+	// Build card body elements
+	body := []AdaptiveElement{
+		{
+			Type:   "TextBlock",
+			Text:   title,
+			Weight: "bolder",
+			Size:   "large",
+			Color:  headerColor(color),
+		},
+	}
+
+	facts := []infoFact{
+		{Label: cfg.label(LabelVersion), Value: releaseCtx.Version},
+		{Label: cfg.label(LabelBranch), Value: releaseCtx.Branch},
+		{Label: cfg.label(LabelTag), Value: releaseCtx.TagName},
+	}
+	if approval := resolveApproval(cfg, releaseCtx); approval != "" {
+		facts = append(facts, infoFact{Label: cfg.label(LabelApprovedBy), Value: approval})
+	}
+	body = append(body, p.layoutSections(cfg, []AdaptiveElement{buildInfo(cfg, facts)})...)
+
+	return notification{
+		status:  StatusApproval,
+		version: releaseCtx.Version,
+		release: releaseCtx,
+		body:    body,
+		color:   color,
+		digest:  buildDigestText(StatusApproval, releaseCtx, ""),
+	}
+}
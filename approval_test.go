@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// infoFacts extracts label/value pairs from the first ColumnSet in body.
+func infoFacts(body []AdaptiveElement) map[string]string {
+	facts := map[string]string{}
+	for _, elem := range body {
+		if elem.Type == "Container" {
+			for k, v := range infoFacts(elem.Items) {
+				facts[k] = v
+			}
+			continue
+		}
+		if elem.Type != "ColumnSet" || len(elem.Columns) != 2 {
+			continue
+		}
+		labels, values := elem.Columns[0].Items, elem.Columns[1].Items
+		for i := range labels {
+			if i < len(values) {
+				facts[labels[i].Text] = values[i].Text
+			}
+		}
+		break
+	}
+	return facts
+}
+
+func TestShowApprover(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		environment map[string]string
+		want        string
+	}{
+		{
+			name:        "from_release_environment",
+			config:      map[string]any{"show_approver": true},
+			environment: map[string]string{EnvApprovedBy: "alice", EnvApprovedAt: "2024-12-17T10:00:00Z"},
+			want:        "alice (2024-12-17T10:00:00Z)",
+		},
+		{
+			name:        "config_overrides_environment",
+			config:      map[string]any{"show_approver": true, "approved_by": "bob"},
+			environment: map[string]string{EnvApprovedBy: "alice"},
+			want:        "bob",
+		},
+		{
+			name:        "disabled",
+			config:      map[string]any{"approved_by": "bob"},
+			environment: map[string]string{EnvApprovedBy: "alice"},
+		},
+		{
+			name:   "no_approver_data",
+			config: map[string]any{"show_approver": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+
+			tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:   plugin.HookPostPublish,
+				Config: tt.config,
+				Context: plugin.ReleaseContext{
+					Version:     "1.0.0",
+					TagName:     "v1.0.0",
+					Environment: tt.environment,
+				},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+			}
+
+			got, ok := infoFacts(decodeCard(t, bodies[0]).Body)["Approved by:"]
+			if tt.want == "" {
+				if ok {
+					t.Errorf("expected no approver fact, got %q", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("expected approver %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApprovalNotification(t *testing.T) {
+	t.Parallel()
+
+	baseConfig := func(enabled bool) map[string]any {
+		return map[string]any{
+			"webhook_url":        "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"notify_on_approval": enabled,
+			"approved_by":        "carol",
+		}
+	}
+	releaseCtx := plugin.ReleaseContext{Version: "2.1.0", TagName: "v2.1.0", Branch: "main"}
+
+	t.Run("disabled_by_default", func(t *testing.T) {
+		p := &TeamsPlugin{httpClient: &MockHTTPClient{}}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostApprove,
+			Config:  map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"},
+			Context: releaseCtx,
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+		}
+		if resp.Message != "Approval notification disabled" {
+			t.Errorf("unexpected message %q", resp.Message)
+		}
+	})
+
+	t.Run("dry_run", func(t *testing.T) {
+		p := &TeamsPlugin{httpClient: &MockHTTPClient{}}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostApprove,
+			Config:  baseConfig(true),
+			Context: releaseCtx,
+			DryRun:  true,
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+		}
+		if resp.Message != "Would send Teams approval notification" {
+			t.Errorf("unexpected message %q", resp.Message)
+		}
+	})
+
+	t.Run("sends_approval_card", func(t *testing.T) {
+		var bodies [][]byte
+		p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostApprove,
+			Config:  baseConfig(true),
+			Context: releaseCtx,
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+		}
+		if resp.Message != "Sent Teams approval notification" {
+			t.Errorf("unexpected message %q", resp.Message)
+		}
+
+		card := decodeCard(t, bodies[0])
+		header := card.Body[0]
+		if header.Text != "Release 2.1.0 Approved" || header.Color != "accent" {
+			t.Errorf("unexpected header %+v", header)
+		}
+		if got := infoFacts(card.Body)["Approved by:"]; got != "carol" {
+			t.Errorf("expected approver carol, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// changeGroup is a heading and the commits listed under it.
+type changeGroup struct {
+	heading string
+	// emoji prefixes the heading when category_emoji is enabled.
+	emoji   string
+	commits []plugin.ConventionalCommit
+}
+
+// Caps on include_change_details: items per category, and items per card so
+// large releases stay within the card size limit.
+const (
+	DefaultChangeDetailsLimit = 5
+	MaxChangeDetailsLimit     = 50
+	maxChangeDetailsTotal     = 30
+)
+
+// unscopedHeading groups commits without a scope when grouping by scope.
+const unscopedHeading = "other"
+
+// categoryGroups returns the non-empty change categories in display order.
+func categoryGroups(changes *plugin.CategorizedChanges) []changeGroup {
+	all := []changeGroup{
+		{heading: "Breaking Changes", emoji: "💥", commits: changes.Breaking},
+		{heading: "Features", emoji: "✨", commits: changes.Features},
+		{heading: "Fixes", emoji: "🐛", commits: changes.Fixes},
+		{heading: "Performance", emoji: "⚡", commits: changes.Performance},
+		{heading: "Refactoring", emoji: "♻️", commits: changes.Refactor},
+		{heading: "Documentation", emoji: "📝", commits: changes.Docs},
+		{heading: "Other", emoji: "🔧", commits: changes.Other},
+	}
+	groups := make([]changeGroup, 0, len(all))
+	for _, g := range all {
+		if len(g.commits) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// scopeGroups regroups commits by conventional commit scope, sorted by scope
+// with unscoped commits last. ok is false when no commit has a scope.
+// Commits listed in several categories (e.g. breaking features) appear once.
+func scopeGroups(changes *plugin.CategorizedChanges) (groups []changeGroup, ok bool) {
+	byScope := make(map[string][]plugin.ConventionalCommit)
+	seen := make(map[string]bool)
+	for _, category := range categoryGroups(changes) {
+		for _, commit := range category.commits {
+			if commit.Hash != "" {
+				if seen[commit.Hash] {
+					continue
+				}
+				seen[commit.Hash] = true
+			}
+			scope := strings.ToLower(strings.TrimSpace(commit.Scope))
+			if scope != "" {
+				ok = true
+			} else {
+				scope = unscopedHeading
+			}
+			byScope[scope] = append(byScope[scope], commit)
+		}
+	}
+	if !ok {
+		return nil, false
+	}
+
+	scopes := make([]string, 0, len(byScope))
+	for scope := range byScope {
+		if scope != unscopedHeading {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	if _, hasUnscoped := byScope[unscopedHeading]; hasUnscoped {
+		scopes = append(scopes, unscopedHeading)
+	}
+
+	groups = make([]changeGroup, 0, len(scopes))
+	for _, scope := range scopes {
+		groups = append(groups, changeGroup{heading: scope, commits: byScope[scope]})
+	}
+	return groups, true
+}
+
+// buildChangeGroups renders the commit descriptions of changes under
+// headings, grouped by scope when byScope is set and any commit has one,
+// and by category otherwise. With emoji, category headings are prefixed
+// with their emoji; scope headings never are. Issue references are linked
+// to issueRepoURL when it is set.
+func buildChangeGroups(changes *plugin.CategorizedChanges, byScope, emoji bool, issueRepoURL string) []AdaptiveElement {
+	if changes == nil {
+		return nil
+	}
+
+	groups := categoryGroups(changes)
+	if byScope {
+		if scoped, ok := scopeGroups(changes); ok {
+			groups = scoped
+		}
+	}
+
+	elements := make([]AdaptiveElement, 0, 2*len(groups))
+	for _, g := range groups {
+		lines := make([]string, 0, len(g.commits))
+		for _, commit := range g.commits {
+			lines = append(lines, changeLine(commit, issueRepoURL))
+		}
+		elements = append(elements, g.elements(lines, emoji)...)
+	}
+	return elements
+}
+
+// buildChangeDetails lists the commit descriptions of the breaking, feature
+// and fix categories, up to perCategory items each and maxChangeDetailsTotal
+// in all. Categories cut short end with "…and N more".
+func buildChangeDetails(changes *plugin.CategorizedChanges, perCategory int, emoji bool, issueRepoURL string) []AdaptiveElement {
+	if changes == nil {
+		return nil
+	}
+
+	budget := maxChangeDetailsTotal
+	var elements []AdaptiveElement
+	for _, g := range categoryGroups(changes) {
+		if g.heading != "Breaking Changes" && g.heading != "Features" && g.heading != "Fixes" {
+			continue
+		}
+		shown := min(len(g.commits), perCategory, budget)
+		budget -= shown
+		lines := make([]string, 0, shown+1)
+		for _, commit := range g.commits[:shown] {
+			lines = append(lines, changeLine(commit, issueRepoURL))
+		}
+		if hidden := len(g.commits) - shown; hidden > 0 {
+			lines = append(lines, fmt.Sprintf("…and %d more", hidden))
+		}
+		elements = append(elements, g.elements(lines, emoji)...)
+	}
+	return elements
+}
+
+// changeLine renders a commit description as a bullet.
+func changeLine(commit plugin.ConventionalCommit, issueRepoURL string) string {
+	return "- " + linkifyIssues(html.EscapeString(commit.Description), issueRepoURL)
+}
+
+// elements renders the group heading above lines.
+func (g changeGroup) elements(lines []string, emoji bool) []AdaptiveElement {
+	heading := html.EscapeString(g.heading)
+	if emoji && g.emoji != "" {
+		heading = g.emoji + " " + heading
+	}
+	return []AdaptiveElement{
+		{Type: "TextBlock", Text: heading, Weight: "bolder", Spacing: "medium"},
+		{Type: "TextBlock", Text: strings.Join(lines, "\n"), Wrap: true, Spacing: "small"},
+	}
+}
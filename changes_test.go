@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// changeGroupTexts flattens rendered change groups into heading/list pairs.
+func changeGroupTexts(elements []AdaptiveElement) [][2]string {
+	var groups [][2]string
+	for i := 0; i+1 < len(elements); i += 2 {
+		groups = append(groups, [2]string{elements[i].Text, elements[i+1].Text})
+	}
+	return groups
+}
+
+func TestBuildChangeGroupsByScope(t *testing.T) {
+	t.Parallel()
+
+	changes := &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{
+			{Hash: "a1", Scope: "ui", Description: "dark mode"},
+			{Hash: "a2", Scope: "API", Description: "pagination"},
+			{Hash: "a3", Description: "faster startup"},
+			{Hash: "a4", Scope: "api", Description: "drop v1 <endpoints>", Breaking: true},
+		},
+		Fixes: []plugin.ConventionalCommit{
+			{Hash: "b1", Scope: "ui", Description: "button alignment"},
+		},
+		Breaking: []plugin.ConventionalCommit{
+			{Hash: "a4", Scope: "api", Description: "drop v1 <endpoints>", Breaking: true},
+		},
+	}
+
+	got := changeGroupTexts(buildChangeGroups(changes, true, true, ""))
+	want := [][2]string{
+		{"api", "- drop v1 &lt;endpoints&gt;\n- pagination"},
+		{"ui", "- dark mode\n- button alignment"},
+		{"other", "- faster startup"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d groups, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("group %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildChangeGroupsFallsBackToCategories(t *testing.T) {
+	t.Parallel()
+
+	changes := &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Description: "export"}},
+		Fixes:    []plugin.ConventionalCommit{{Description: "crash"}, {Description: "typo"}},
+	}
+
+	got := changeGroupTexts(buildChangeGroups(changes, true, false, ""))
+	want := [][2]string{
+		{"Features", "- export"},
+		{"Fixes", "- crash\n- typo"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d groups, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("group %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGroupByScopeRendered(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version: "1.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Scope: "core", Description: "plugins"}},
+		},
+	}
+
+	for _, enabled := range []bool{true, false} {
+		n := (&TeamsPlugin{}).buildSuccessNotification(&Config{GroupByScope: enabled}, releaseCtx)
+		found := false
+		for _, elem := range n.body {
+			if elem.Text == "core" && elem.Weight == "bolder" {
+				found = true
+			}
+		}
+		if found != enabled {
+			t.Errorf("group_by_scope=%v: expected scope heading present=%v", enabled, enabled)
+		}
+	}
+}
+
+func TestCategoryEmoji(t *testing.T) {
+	t.Parallel()
+
+	changes := &plugin.CategorizedChanges{
+		Breaking: []plugin.ConventionalCommit{{Description: "drop v1"}},
+		Features: []plugin.ConventionalCommit{{Description: "export"}},
+		Fixes:    []plugin.ConventionalCommit{{Description: "crash"}},
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   []string
+	}{
+		{
+			name:   "detailed",
+			config: map[string]any{"style_preset": "detailed"},
+			want:   []string{"💥 Breaking Changes", "✨ Features", "🐛 Fixes"},
+		},
+		{
+			name:   "detailed_disabled",
+			config: map[string]any{"style_preset": "detailed", "category_emoji": false},
+			want:   []string{"Breaking Changes", "Features", "Fixes"},
+		},
+		{
+			name:   "enabled_without_preset",
+			config: map[string]any{"group_by_scope": true, "category_emoji": true},
+			want:   []string{"💥 Breaking Changes", "✨ Features", "🐛 Fixes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := (&TeamsPlugin{}).parseConfig(tt.config)
+			n := (&TeamsPlugin{}).buildSuccessNotification(cfg, plugin.ReleaseContext{Version: "1.0.0", Changes: changes})
+
+			var headings []string
+			for _, elem := range n.body {
+				if elem.Weight == "bolder" && elem.Size == "" {
+					headings = append(headings, elem.Text)
+				}
+			}
+			if len(headings) != len(tt.want) {
+				t.Fatalf("expected headings %q, got %q", tt.want, headings)
+			}
+			for i := range tt.want {
+				if headings[i] != tt.want[i] {
+					t.Errorf("heading %d: got %q, want %q", i, headings[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryEmojiSkipsScopeHeadings(t *testing.T) {
+	t.Parallel()
+
+	changes := &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{{Scope: "ui", Description: "dark mode"}},
+	}
+	if got := changeGroupTexts(buildChangeGroups(changes, true, true, "")); got[0][0] != "ui" {
+		t.Errorf("expected a plain scope heading, got %q", got[0][0])
+	}
+}
+
+// commits returns n commits described as prefix-1 to prefix-n.
+func commits(prefix string, n int) []plugin.ConventionalCommit {
+	out := make([]plugin.ConventionalCommit, n)
+	for i := range out {
+		out[i] = plugin.ConventionalCommit{Description: fmt.Sprintf("%s-%d", prefix, i+1)}
+	}
+	return out
+}
+
+func TestBuildChangeDetails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		changes     *plugin.CategorizedChanges
+		perCategory int
+		want        [][2]string
+	}{
+		{
+			name: "under_limit",
+			changes: &plugin.CategorizedChanges{
+				Features: []plugin.ConventionalCommit{{Description: "export <csv>"}},
+				Fixes:    commits("fix", 2),
+				Docs:     commits("docs", 1),
+			},
+			perCategory: 5,
+			want: [][2]string{
+				{"Features", "- export &lt;csv&gt;"},
+				{"Fixes", "- fix-1\n- fix-2"},
+			},
+		},
+		{
+			name: "per_category_limit",
+			changes: &plugin.CategorizedChanges{
+				Breaking: commits("breaking", 1),
+				Fixes:    commits("fix", 4),
+			},
+			perCategory: 2,
+			want: [][2]string{
+				{"Breaking Changes", "- breaking-1"},
+				{"Fixes", "- fix-1\n- fix-2\n…and 2 more"},
+			},
+		},
+		{
+			name: "total_limit",
+			changes: &plugin.CategorizedChanges{
+				Breaking: commits("breaking", 20),
+				Features: commits("feat", 20),
+				Fixes:    commits("fix", 3),
+			},
+			perCategory: 20,
+			want: [][2]string{
+				{"Breaking Changes", strings.Join(changeTexts(commits("breaking", 20)), "\n")},
+				{"Features", strings.Join(changeTexts(commits("feat", 10)), "\n") + "\n…and 10 more"},
+				{"Fixes", "…and 3 more"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := changeGroupTexts(buildChangeDetails(tt.changes, tt.perCategory, false, ""))
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d groups, got %q", len(tt.want), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("group %d: got %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// changeTexts renders commits as change detail bullets.
+func changeTexts(commits []plugin.ConventionalCommit) []string {
+	lines := make([]string, len(commits))
+	for i, c := range commits {
+		lines[i] = "- " + c.Description
+	}
+	return lines
+}
+
+func TestIncludeChangeDetailsRendered(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version: "1.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Scope: "core", Description: "plugins"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   string
+	}{
+		{name: "disabled", config: map[string]any{}},
+		{name: "enabled", config: map[string]any{"include_change_details": true}, want: "Features"},
+		{name: "group_by_scope_wins", config: map[string]any{"include_change_details": true, "group_by_scope": true}, want: "core"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := (&TeamsPlugin{}).parseConfig(tt.config)
+			n := (&TeamsPlugin{}).buildSuccessNotification(cfg, releaseCtx)
+			var headings []string
+			for _, elem := range n.body {
+				if elem.Weight == "bolder" && elem.Size == "" {
+					headings = append(headings, elem.Text)
+				}
+			}
+			if tt.want == "" {
+				if len(headings) != 0 {
+					t.Errorf("expected no change headings, got %q", headings)
+				}
+				return
+			}
+			if len(headings) != 1 || headings[0] != tt.want {
+				t.Errorf("expected the heading %q, got %q", tt.want, headings)
+			}
+		})
+	}
+}
+
+func TestValidateChangeDetailsLimit(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for limit, wantValid := range map[int]bool{1: true, 50: true, 0: false, 51: false} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":          "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"change_details_limit": limit,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("change_details_limit=%d: expected Valid=%v, got %+v", limit, wantValid, resp.Errors)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ciProvider describes how to detect a CI system from its environment.
+type ciProvider struct {
+	name string
+	// detect is an environment variable the provider always sets.
+	detect string
+	// events are environment variables naming the triggering event, in order.
+	events []string
+}
+
+// ciProviders are checked in order; the first whose detect variable is set wins.
+var ciProviders = []ciProvider{
+	{name: "GitHub Actions", detect: "GITHUB_ACTIONS", events: []string{"GITHUB_EVENT_NAME"}},
+	{name: "GitLab CI", detect: "GITLAB_CI", events: []string{"CI_PIPELINE_SOURCE"}},
+	{name: "Azure Pipelines", detect: "TF_BUILD", events: []string{"BUILD_REASON"}},
+	{name: "CircleCI", detect: "CIRCLECI"},
+	{name: "Bitbucket Pipelines", detect: "BITBUCKET_BUILD_NUMBER"},
+	{name: "Buildkite", detect: "BUILDKITE", events: []string{"BUILDKITE_SOURCE"}},
+	{name: "Travis CI", detect: "TRAVIS", events: []string{"TRAVIS_EVENT_TYPE"}},
+	{name: "Jenkins", detect: "JENKINS_URL"},
+}
+
+// detectCIProvider names the CI provider and triggering event from the
+// environment, e.g. "GitHub Actions (push)", or returns "" outside CI.
+func detectCIProvider(getenv func(string) string) string {
+	for _, provider := range ciProviders {
+		value := strings.TrimSpace(getenv(provider.detect))
+		if value == "" {
+			continue
+		}
+		// Flags such as GITLAB_CI=false mean the variable is set but not in CI
+		if set, err := strconv.ParseBool(value); err == nil && !set {
+			continue
+		}
+		for _, event := range provider.events {
+			if e := strings.TrimSpace(getenv(event)); e != "" {
+				return provider.name + " (" + e + ")"
+			}
+		}
+		return provider.name
+	}
+	return ""
+}
+
+// resolveCIProvider returns ci_provider when set, otherwise the detected provider.
+func resolveCIProvider(cfg *Config) string {
+	if cfg.CIProvider != "" {
+		return cfg.CIProvider
+	}
+	return detectCIProvider(os.Getenv)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestDetectCIProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "none", env: map[string]string{}, want: ""},
+		{
+			name: "github_actions",
+			env:  map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_EVENT_NAME": "push"},
+			want: "GitHub Actions (push)",
+		},
+		{
+			name: "gitlab_ci",
+			env:  map[string]string{"GITLAB_CI": "true", "CI_PIPELINE_SOURCE": "web"},
+			want: "GitLab CI (web)",
+		},
+		{
+			name: "azure_pipelines",
+			env:  map[string]string{"TF_BUILD": "True", "BUILD_REASON": "IndividualCI"},
+			want: "Azure Pipelines (IndividualCI)",
+		},
+		{
+			name: "without_event",
+			env:  map[string]string{"CIRCLECI": "true"},
+			want: "CircleCI",
+		},
+		{
+			name: "url_flag",
+			env:  map[string]string{"JENKINS_URL": "https://jenkins.example.com/"},
+			want: "Jenkins",
+		},
+		{
+			name: "disabled_flag",
+			env:  map[string]string{"GITLAB_CI": "false"},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectCIProvider(getenv); got != tt.want {
+				t.Errorf("detectCIProvider() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShowCIProvider(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_EVENT_NAME", "workflow_dispatch")
+
+	p := &TeamsPlugin{}
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0"}
+
+	n := p.buildSuccessNotification(&Config{ShowInfo: true, ShowCIProvider: true}, releaseCtx)
+	if got := infoFacts(n.body)["Triggered by:"]; got != "GitHub Actions (workflow_dispatch)" {
+		t.Errorf("expected the detected provider, got %q", got)
+	}
+
+	n = p.buildSuccessNotification(&Config{ShowInfo: true, ShowCIProvider: true, CIProvider: "Drone (tag)"}, releaseCtx)
+	if got := infoFacts(n.body)["Triggered by:"]; got != "Drone (tag)" {
+		t.Errorf("expected ci_provider to override detection, got %q", got)
+	}
+
+	n = p.buildSuccessNotification(&Config{}, releaseCtx)
+	if _, ok := infoFacts(n.body)["Triggered by:"]; ok {
+		t.Error("expected no provider fact without show_ci_provider")
+	}
+}
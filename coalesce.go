@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Release environment keys describing failures reported by OnError hooks.
+const (
+	// EnvErrorMessage describes the failure; it is shown on error cards.
+	EnvErrorMessage = "RELICTA_ERROR"
+	// EnvFinalError marks the last OnError hook of a run, which flushes the
+	// buffer. The host does not set it; pipelines may set it on their last
+	// failure step to flush without waiting for the window to end or a
+	// success hook.
+	EnvFinalError = "RELICTA_FINAL_ERROR"
+)
+
+// Coalescing defaults: the window within which later failures of a version
+// are buffered behind the first, and the buffered count that forces a flush.
+const (
+	DefaultCoalesceWindowSeconds = 60
+	DefaultCoalesceMaxErrors     = 5
+	MaxCoalesceMaxErrors         = 100
+)
+
+// errorWindow is the coalescing state of one release version: when its
+// window opened and the failures buffered since.
+type errorWindow struct {
+	opened  time.Time
+	pending []plugin.ReleaseContext
+}
+
+// errorBuffer collects failures per release version until they are flushed.
+type errorBuffer struct {
+	mu      sync.Mutex
+	windows map[string]*errorWindow
+}
+
+func newErrorBuffer() *errorBuffer {
+	return &errorBuffer{windows: make(map[string]*errorWindow)}
+}
+
+// add records a failure at now and returns the failures to send with it,
+// or nil when it stays buffered, along with the number pending and the time
+// left in the window. The first failure of a window is sent at once,
+// together with anything left over from an expired window. Later failures
+// in the window are buffered until one is marked final or maxPending are
+// waiting. Expired windows of other versions are dropped once nothing is
+// pending in them, so the buffer can't grow without bound.
+func (b *errorBuffer) add(releaseCtx plugin.ReleaseContext, now time.Time, window time.Duration, maxPending int, final bool) (send []plugin.ReleaseContext, pending int, remaining time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for version, w := range b.windows {
+		if version != releaseCtx.Version && len(w.pending) == 0 && now.Sub(w.opened) >= window {
+			delete(b.windows, version)
+		}
+	}
+
+	w, ok := b.windows[releaseCtx.Version]
+	if !ok || now.Sub(w.opened) >= window {
+		var leftover []plugin.ReleaseContext
+		if ok {
+			leftover = w.pending
+		}
+		b.windows[releaseCtx.Version] = &errorWindow{opened: now}
+		return append(leftover, releaseCtx), 0, window
+	}
+
+	w.pending = append(w.pending, releaseCtx)
+	remaining = window - now.Sub(w.opened)
+	if final || len(w.pending) >= maxPending {
+		send, w.pending = w.pending, nil
+		return send, 0, remaining
+	}
+	return nil, len(w.pending), remaining
+}
+
+// take removes and returns the failures buffered for version.
+func (b *errorBuffer) take(version string) []plugin.ReleaseContext {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	w, ok := b.windows[version]
+	if !ok {
+		return nil
+	}
+	pending := w.pending
+	w.pending = nil
+	return pending
+}
+
+// defaultErrorBuffer is shared by plugin instances without an injected buffer,
+// so failures reported by separate hook invocations in one process coalesce.
+var defaultErrorBuffer = newErrorBuffer()
+
+// getErrorBuffer returns the error buffer to use.
+func (p *TeamsPlugin) getErrorBuffer() *errorBuffer {
+	if p.errorBuffer != nil {
+		return p.errorBuffer
+	}
+	return defaultErrorBuffer
+}
+
+// isFinalError reports whether the release environment marks this as the last failure.
+func isFinalError(releaseCtx plugin.ReleaseContext) bool {
+	final, _ := strconv.ParseBool(releaseCtx.Environment[EnvFinalError])
+	return final
+}
+
+// sendCoalescedErrorNotification sends the first failure of a version at
+// once and buffers later ones within coalesce_window_seconds. Buffered
+// failures are sent as one summary card when the window ends, when a success
+// hook runs for the version, when a failure is marked final by
+// RELICTA_FINAL_ERROR, or when coalesce_max_errors are waiting. A buffered
+// hook succeeds: its failure is delivered with the summary.
+func (p *TeamsPlugin) sendCoalescedErrorNotification(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	window := time.Duration(cfg.CoalesceWindowSeconds) * time.Second
+	failures, pending, remaining := p.getErrorBuffer().add(releaseCtx, p.currentTime(), window, cfg.CoalesceMaxErrors, isFinalError(releaseCtx))
+	if failures == nil {
+		p.getLogger().Debug("error notification buffered", "version", releaseCtx.Version, "pending", pending)
+		if pending == 1 {
+			// The hook's context ends when it returns; the flush outlives it
+			flushCtx := context.WithoutCancel(ctx)
+			p.afterDelay(remaining, func() {
+				p.flushBufferedErrors(flushCtx, cfg, releaseCtx.Version, dryRun)
+			})
+		}
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: fmt.Sprintf("Error notification buffered (%d pending)", pending),
+		}, nil
+	}
+	return p.sendErrors(ctx, cfg, failures, dryRun), nil
+}
+
+// flushBufferedErrors sends the failures still buffered for version, if any.
+// Nothing waits on the result, so a failed send is only logged.
+func (p *TeamsPlugin) flushBufferedErrors(ctx context.Context, cfg *Config, version string, dryRun bool) {
+	failures := p.getErrorBuffer().take(version)
+	if len(failures) == 0 {
+		return
+	}
+	if resp := p.sendErrors(ctx, cfg, failures, dryRun); !resp.Success {
+		p.getLogger().Error("buffered error notifications not sent", "version", version, "count", len(failures), "error", resp.Error)
+	}
+}
+
+// sendErrors sends one failure as an error card and several as a summary card.
+func (p *TeamsPlugin) sendErrors(ctx context.Context, cfg *Config, failures []plugin.ReleaseContext, dryRun bool) *plugin.ExecuteResponse {
+	if len(failures) == 1 {
+		return p.dispatch(ctx, cfg, p.buildErrorNotification(cfg, failures[0]), dryRun)
+	}
+	return p.dispatch(ctx, cfg, p.buildErrorSummaryNotification(cfg, failures), dryRun)
+}
+
+// buildErrorSummaryNotification builds one error card listing several failures.
+// The last failure provides the version and branch shown.
+func (p *TeamsPlugin) buildErrorSummaryNotification(cfg *Config, failures []plugin.ReleaseContext) notification {
+	// The failure list below replaces the single error message
+	last := failures[len(failures)-1]
+	last.Environment = maps.Clone(last.Environment)
+	delete(last.Environment, EnvErrorMessage)
+
+	n := p.buildErrorNotification(cfg, last)
+	n.body[0].Text = withIcon(cfg.ErrorIcon,
+		fmt.Sprintf("Release %s Failed (%d failures)", last.Version, len(failures)))
+
+	lines := make([]string, 0, len(failures))
+	for i, failure := range failures {
+		description := failure.Environment[EnvErrorMessage]
+		if description == "" {
+			description = fmt.Sprintf("Failure %d", i+1)
+		}
+		lines = append(lines, "- "+html.EscapeString(cfg.redactErrorText(description)))
+	}
+	n.body = append(n.body, AdaptiveElement{
+		Type:      "TextBlock",
+		Text:      strings.Join(lines, "\n"),
+		Wrap:      true,
+		Separator: true,
+		Spacing:   "medium",
+	})
+	return n
+}
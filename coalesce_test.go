@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// coalesceStep is one OnError hook invocation in a coalescing test.
+type coalesceStep struct {
+	after    time.Duration // since the first step
+	env      map[string]string
+	wantSent string // title of the card sent, or "" when buffered
+}
+
+func runCoalesceSteps(t *testing.T, config map[string]any, steps []coalesceStep) *TeamsPlugin {
+	t.Helper()
+
+	start := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	var now time.Time
+	var bodies [][]byte
+	p := &TeamsPlugin{
+		httpClient:  recordingClient(&bodies),
+		errorBuffer: newErrorBuffer(),
+		now:         func() time.Time { return now },
+		afterFunc:   func(time.Duration, func()) {},
+	}
+	config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+	config["coalesce_errors"] = true
+
+	for i, step := range steps {
+		now = start.Add(step.after)
+		sent := len(bodies)
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookOnError,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0", Branch: "main", Environment: step.env},
+		})
+		if err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+		if step.wantSent == "" {
+			if !resp.Success || len(bodies) != sent || !strings.Contains(resp.Message, "buffered") {
+				t.Errorf("step %d: expected the failure to be buffered, got %+v", i, resp)
+			}
+			continue
+		}
+		if !resp.Success || len(bodies) != sent+1 {
+			t.Fatalf("step %d: expected a card to be sent, got %+v", i, resp)
+		}
+		if title := decodeCard(t, bodies[sent]).Body[0].Text; title != step.wantSent {
+			t.Errorf("step %d: expected title %q, got %q", i, step.wantSent, title)
+		}
+	}
+	return p
+}
+
+func TestCoalesceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		steps  []coalesceStep
+	}{
+		{
+			name:   "first_sent_at_once",
+			config: map[string]any{},
+			steps:  []coalesceStep{{wantSent: "Release 1.0.0 Failed"}},
+		},
+		{
+			name:   "final_flushes",
+			config: map[string]any{},
+			steps: []coalesceStep{
+				{env: map[string]string{EnvErrorMessage: "build failed"}, wantSent: "Release 1.0.0 Failed"},
+				{after: time.Second, env: map[string]string{EnvErrorMessage: "tests failed"}},
+				{after: 2 * time.Second, env: map[string]string{EnvErrorMessage: "publish failed", EnvFinalError: "true"}, wantSent: "Release 1.0.0 Failed (2 failures)"},
+			},
+		},
+		{
+			name:   "size_bound_flushes",
+			config: map[string]any{"coalesce_max_errors": 3},
+			steps: []coalesceStep{
+				{wantSent: "Release 1.0.0 Failed"},
+				{after: time.Second},
+				{after: 2 * time.Second},
+				{after: 3 * time.Second, wantSent: "Release 1.0.0 Failed (3 failures)"},
+			},
+		},
+		{
+			name:   "window_end_flushes",
+			config: map[string]any{"coalesce_window_seconds": 30},
+			steps: []coalesceStep{
+				{wantSent: "Release 1.0.0 Failed"},
+				{after: 10 * time.Second},
+				{after: 31 * time.Second, wantSent: "Release 1.0.0 Failed (2 failures)"},
+				{after: 40 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			runCoalesceSteps(t, tt.config, tt.steps)
+		})
+	}
+}
+
+func TestCoalesceErrorsFlushesTrailingFailures(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+	failure := plugin.ExecuteRequest{
+		Hook:    plugin.HookOnError,
+		Config:  map[string]any{"webhook_url": webhook, "coalesce_errors": true, "coalesce_window_seconds": 30},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	}
+
+	t.Run("window_end", func(t *testing.T) {
+		t.Parallel()
+
+		var bodies [][]byte
+		var delays []time.Duration
+		var flush func()
+		start := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+		now := start
+		p := &TeamsPlugin{
+			httpClient:  recordingClient(&bodies),
+			errorBuffer: newErrorBuffer(),
+			now:         func() time.Time { return now },
+			afterFunc: func(d time.Duration, f func()) {
+				delays = append(delays, d)
+				flush = f
+			},
+		}
+
+		for i := range 3 {
+			now = start.Add(time.Duration(i) * 5 * time.Second)
+			if resp, err := p.Execute(context.Background(), failure); err != nil || !resp.Success {
+				t.Fatalf("failure %d: unexpected response %+v (%v)", i, resp, err)
+			}
+		}
+		if len(bodies) != 1 || len(delays) != 1 || delays[0] != 25*time.Second {
+			t.Fatalf("expected one card and a flush at the window end, got %d cards and delays %v", len(bodies), delays)
+		}
+
+		flush()
+		if len(bodies) != 2 {
+			t.Fatalf("expected the buffered failures to be sent, got %d cards", len(bodies))
+		}
+		if title := decodeCard(t, bodies[1]).Body[0].Text; title != "Release 1.0.0 Failed (2 failures)" {
+			t.Errorf("unexpected summary title %q", title)
+		}
+		flush()
+		if len(bodies) != 2 {
+			t.Errorf("expected nothing left to flush, got %d cards", len(bodies))
+		}
+	})
+
+	t.Run("success_hook", func(t *testing.T) {
+		t.Parallel()
+
+		var bodies [][]byte
+		p := &TeamsPlugin{
+			httpClient:  recordingClient(&bodies),
+			errorBuffer: newErrorBuffer(),
+			afterFunc:   func(time.Duration, func()) {},
+		}
+		for i := range 2 {
+			if resp, err := p.Execute(context.Background(), failure); err != nil || !resp.Success {
+				t.Fatalf("failure %d: unexpected response %+v (%v)", i, resp, err)
+			}
+		}
+
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookOnSuccess,
+			Config:  failure.Config,
+			Context: failure.Context,
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %+v", err, resp)
+		}
+		if len(bodies) != 3 {
+			t.Fatalf("expected the error, the buffered error and the success card, got %d cards", len(bodies))
+		}
+		if title := decodeCard(t, bodies[1]).Body[0].Text; title != "Release 1.0.0 Failed" {
+			t.Errorf("expected the buffered failure before the success card, got %q", title)
+		}
+	})
+}
+
+func TestCoalesceErrorsSummary(t *testing.T) {
+	t.Parallel()
+
+	n := (&TeamsPlugin{}).buildErrorSummaryNotification(&Config{}, []plugin.ReleaseContext{
+		{Version: "1.0.0", Environment: map[string]string{EnvErrorMessage: "tests <failed>"}},
+		{Version: "1.0.0", Environment: map[string]string{EnvErrorMessage: "publish failed"}},
+		{Version: "1.0.0"},
+	})
+	list := n.body[len(n.body)-1].Text
+	for _, want := range []string{"- tests &lt;failed&gt;", "- publish failed", "- Failure 3"} {
+		if !strings.Contains(list, want) {
+			t.Errorf("expected %q in failure list, got %q", want, list)
+		}
+	}
+}
+
+func TestErrorBufferDropsExpiredVersions(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	b := newErrorBuffer()
+	b.add(plugin.ReleaseContext{Version: "1.0.0"}, start, time.Minute, 5, false)
+	b.add(plugin.ReleaseContext{Version: "1.0.0"}, start.Add(time.Second), time.Minute, 5, false)
+	b.add(plugin.ReleaseContext{Version: "2.0.0"}, start.Add(2*time.Minute), time.Minute, 5, false)
+	if _, ok := b.windows["1.0.0"]; !ok {
+		t.Fatal("expected an expired window with a pending failure to be kept for its flush")
+	}
+
+	b.take("1.0.0")
+	b.add(plugin.ReleaseContext{Version: "2.0.0"}, start.Add(3*time.Minute), time.Minute, 5, false)
+	if _, ok := b.windows["1.0.0"]; ok || len(b.windows) != 1 {
+		t.Errorf("expected the expired window to be dropped, got %d windows", len(b.windows))
+	}
+}
+
+func TestValidateCoalesce(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for name, tc := range map[string]struct {
+		config    map[string]any
+		wantValid bool
+	}{
+		"defaults":      {config: map[string]any{"coalesce_errors": true}, wantValid: true},
+		"zero_window":   {config: map[string]any{"coalesce_window_seconds": 0}},
+		"zero_max":      {config: map[string]any{"coalesce_max_errors": 0}},
+		"max_too_large": {config: map[string]any{"coalesce_max_errors": MaxCoalesceMaxErrors + 1}},
+	} {
+		tc.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+		resp, err := p.Validate(context.Background(), tc.config)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if resp.Valid != tc.wantValid {
+			t.Errorf("%s: expected Valid=%v, got %+v", name, tc.wantValid, resp.Errors)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// ColorWarning is the card color for warnings and breaking releases.
+const ColorWarning = "FD7E14" // Orange
+
+// ColorKindWarning is the color kind for warning notifications. The other
+// kinds are the notification statuses.
+const ColorKindWarning = "warning"
+
+// ColorKindBreaking is the color_overrides key for releases with breaking changes.
+const ColorKindBreaking = "breaking"
+
+// EnvReleaseEnvironment names the deployment environment of the release,
+// used to look up environment_colors.
+const EnvReleaseEnvironment = "RELICTA_ENVIRONMENT"
+
+// resolveCardColor returns the hex color of a card of the given kind
+// (success, error, warning or approval). The first match wins:
+//
+//  1. color_overrides for the kind (or "breaking" for breaking successes)
+//  2. error and warning kinds keep their status color
+//  3. breaking changes turn a success card orange
+//  4. environment_colors for the release environment
+//  5. an explicitly configured theme_color
+//  6. the default color for the kind
+func resolveCardColor(kind string, releaseCtx plugin.ReleaseContext, cfg *Config) string {
+	breaking := kind == StatusSuccess && releaseCtx.Changes != nil && len(releaseCtx.Changes.Breaking) > 0
+
+	if breaking {
+		if color := cfg.ColorOverrides[ColorKindBreaking]; color != "" {
+			return color
+		}
+	}
+	if color := cfg.ColorOverrides[kind]; color != "" {
+		return color
+	}
+
+	switch kind {
+	case StatusError:
+		return ColorError
+	case ColorKindWarning:
+		return ColorWarning
+	}
+
+	if breaking {
+		return ColorWarning
+	}
+	if env := releaseCtx.Environment[EnvReleaseEnvironment]; env != "" {
+		if color := cfg.EnvironmentColors[strings.ToLower(env)]; color != "" {
+			return color
+		}
+	}
+	if theme := strings.ToUpper(strings.TrimPrefix(cfg.ThemeColor, "#")); theme != "" && theme != DefaultThemeColor {
+		return theme
+	}
+
+	if kind == StatusApproval {
+		return ColorApproval
+	}
+	return ColorSuccess
+}
+
+// headerColor maps a card color to the nearest Adaptive Card text color,
+// since TextBlocks only accept named colors.
+func headerColor(color string) string {
+	switch strings.ToUpper(color) {
+	case ColorSuccess:
+		return "good"
+	case ColorError:
+		return "attention"
+	case ColorWarning:
+		return "warning"
+	default:
+		return "accent"
+	}
+}
+
+// colorOverrideKinds are the keys accepted by color_overrides.
+var colorOverrideKinds = map[string]bool{
+	StatusSuccess:     true,
+	StatusError:       true,
+	StatusApproval:    true,
+	ColorKindWarning:  true,
+	ColorKindBreaking: true,
+}
+
+// parseColorMap normalizes a color map: lowercase keys and uppercase hex
+// values without a leading #.
+func parseColorMap(raw map[string]any) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	colors := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if s, ok := value.(string); ok && s != "" {
+			colors[strings.ToLower(key)] = strings.ToUpper(strings.TrimPrefix(s, "#"))
+		}
+	}
+	return colors
+}
+
+// isHexColor reports whether s is a 6-digit hex color, with or without #.
+func isHexColor(s string) bool {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return false
+	}
+	for _, c := range s {
+		isDigit := c >= '0' && c <= '9'
+		isLowerHex := c >= 'a' && c <= 'f'
+		isUpperHex := c >= 'A' && c <= 'F'
+		if !isDigit && !isLowerHex && !isUpperHex {
+			return false
+		}
+	}
+	return true
+}
+
+// validateColorMap checks that every value of a color map is a hex color and,
+// when kinds is set, that every key is a known kind.
+func validateColorMap(vb *helpers.ValidationBuilder, field string, raw map[string]any, kinds map[string]bool) {
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if kinds != nil && !kinds[strings.ToLower(key)] {
+			vb.AddErrorWithCode(field,
+				fmt.Sprintf("%s key %q must be one of: success, error, warning, approval, breaking", field, key),
+				"format")
+			continue
+		}
+		if s, ok := raw[key].(string); !ok || !isHexColor(s) {
+			vb.AddErrorWithCode(field,
+				fmt.Sprintf("%s[%s] must be a 6-character hex color (e.g., '0076D7')", field, key),
+				"format")
+		}
+	}
+}
+
+// MinThemeContrast is the lowest WCAG contrast ratio validate_contrast
+// accepts between theme_color and either white or black text. Teams renders
+// cards in light and dark themes, so the color must hold up against both.
+// 3:1 is the WCAG AA minimum for large text and UI components.
+const MinThemeContrast = 3.0
+
+// relativeLuminance returns the WCAG relative luminance of a 6-digit hex
+// color, from 0 for black to 1 for white.
+func relativeLuminance(hex string) float64 {
+	value, _ := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	channel := func(shift uint) float64 {
+		c := float64((value>>shift)&0xFF) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(16) + 0.7152*channel(8) + 0.0722*channel(0)
+}
+
+// contrastRatio returns the WCAG contrast ratio of two relative luminances,
+// from 1 (none) to 21 (black on white).
+func contrastRatio(a, b float64) float64 {
+	return (math.Max(a, b) + 0.05) / (math.Min(a, b) + 0.05)
+}
+
+// contrastWarning describes a hex color whose contrast against white or black
+// text is below MinThemeContrast, or returns "".
+func contrastWarning(field, hex string) string {
+	luminance := relativeLuminance(hex)
+	for _, text := range []struct {
+		name      string
+		luminance float64
+	}{
+		{"white", 1},
+		{"black", 0},
+	} {
+		if ratio := contrastRatio(luminance, text.luminance); ratio < MinThemeContrast {
+			return fmt.Sprintf("%s %s has a contrast ratio of %.1f:1 against %s text, below the %.0f:1 WCAG minimum; text may be hard to read",
+				field, strings.ToUpper(strings.TrimPrefix(hex, "#")), ratio, text.name, MinThemeContrast)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestResolveCardColor(t *testing.T) {
+	t.Parallel()
+
+	breaking := &plugin.CategorizedChanges{
+		Breaking: []plugin.ConventionalCommit{{Hash: "a1", Type: "feat", Breaking: true}},
+	}
+	production := map[string]string{EnvReleaseEnvironment: "Production"}
+
+	tests := []struct {
+		name       string
+		kind       string
+		releaseCtx plugin.ReleaseContext
+		cfg        Config
+		want       string
+	}{
+		{name: "success_default", kind: StatusSuccess, want: ColorSuccess},
+		{name: "error_default", kind: StatusError, want: ColorError},
+		{name: "warning_default", kind: ColorKindWarning, want: ColorWarning},
+		{name: "approval_default", kind: StatusApproval, want: ColorApproval},
+		{
+			name: "default_theme_color_ignored",
+			kind: StatusSuccess,
+			cfg:  Config{ThemeColor: DefaultThemeColor},
+			want: ColorSuccess,
+		},
+		{
+			name: "theme_color_applies_to_success",
+			kind: StatusSuccess,
+			cfg:  Config{ThemeColor: "#6f42c1"},
+			want: "6F42C1",
+		},
+		{
+			name: "theme_color_never_hides_errors",
+			kind: StatusError,
+			cfg:  Config{ThemeColor: "6F42C1"},
+			want: ColorError,
+		},
+		{
+			name:       "environment_beats_theme",
+			kind:       StatusSuccess,
+			releaseCtx: plugin.ReleaseContext{Environment: production},
+			cfg:        Config{ThemeColor: "6F42C1", EnvironmentColors: map[string]string{"production": "000000"}},
+			want:       "000000",
+		},
+		{
+			name:       "breaking_beats_environment",
+			kind:       StatusSuccess,
+			releaseCtx: plugin.ReleaseContext{Changes: breaking, Environment: production},
+			cfg:        Config{EnvironmentColors: map[string]string{"production": "000000"}},
+			want:       ColorWarning,
+		},
+		{
+			name:       "breaking_override",
+			kind:       StatusSuccess,
+			releaseCtx: plugin.ReleaseContext{Changes: breaking},
+			cfg:        Config{ColorOverrides: map[string]string{"breaking": "FF0000", "success": "00FF00"}},
+			want:       "FF0000",
+		},
+		{
+			name:       "kind_override_beats_breaking",
+			kind:       StatusSuccess,
+			releaseCtx: plugin.ReleaseContext{Changes: breaking},
+			cfg:        Config{ColorOverrides: map[string]string{"success": "00FF00"}},
+			want:       "00FF00",
+		},
+		{
+			name: "error_override",
+			kind: StatusError,
+			cfg:  Config{ColorOverrides: map[string]string{"error": "800000"}},
+			want: "800000",
+		},
+		{
+			name:       "environment_does_not_apply_to_errors",
+			kind:       StatusError,
+			releaseCtx: plugin.ReleaseContext{Environment: production},
+			cfg:        Config{EnvironmentColors: map[string]string{"production": "000000"}},
+			want:       ColorError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := resolveCardColor(tt.kind, tt.releaseCtx, &tt.cfg); got != tt.want {
+				t.Errorf("resolveCardColor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakingReleaseHeaderColor(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	n := p.buildSuccessNotification(&Config{}, plugin.ReleaseContext{
+		Version: "2.0.0",
+		Changes: &plugin.CategorizedChanges{Breaking: []plugin.ConventionalCommit{{Hash: "a1"}}},
+	})
+	if n.color != ColorWarning || n.body[0].Color != "warning" {
+		t.Errorf("expected orange card with warning header, got color=%s header=%s", n.color, n.body[0].Color)
+	}
+}
+
+func TestValidateColorMaps(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url":        "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"color_overrides":    map[string]any{"success": "#00ff00", "sparkle": "FFFFFF"},
+		"environment_colors": map[string]any{"production": "red"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || len(resp.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %+v", resp.Errors)
+	}
+	if resp.Errors[0].Field != "color_overrides" || resp.Errors[1].Field != "environment_colors" {
+		t.Errorf("unexpected errors: %+v", resp.Errors)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	t.Parallel()
+
+	if got := contrastRatio(relativeLuminance("000000"), relativeLuminance("#FFFFFF")); math.Abs(got-21) > 0.01 {
+		t.Errorf("expected black on white to be 21:1, got %.2f", got)
+	}
+	if got := contrastRatio(relativeLuminance("777777"), 1); math.Abs(got-4.48) > 0.01 {
+		t.Errorf("expected 777777 on white to be 4.48:1, got %.2f", got)
+	}
+}
+
+func TestValidateContrast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		wantWarning string
+	}{
+		{name: "default_theme", config: map[string]any{"validate_contrast": true}},
+		{name: "high_contrast", config: map[string]any{"validate_contrast": true, "theme_color": "6264A7"}},
+		{
+			name:        "low_contrast_on_white",
+			config:      map[string]any{"validate_contrast": true, "theme_color": "#ffff66"},
+			wantWarning: "warning: theme_color FFFF66 has a contrast ratio of 1.1:1 against white text, below the 3:1 WCAG minimum; text may be hard to read",
+		},
+		{
+			name:        "low_contrast_on_black",
+			config:      map[string]any{"validate_contrast": true, "theme_color": "000080"},
+			wantWarning: "warning: theme_color 000080 has a contrast ratio of 1.3:1 against black text, below the 3:1 WCAG minimum; text may be hard to read",
+		},
+		{name: "disabled", config: map[string]any{"theme_color": "FFFF66"}},
+	}
+
+	p := &TeamsPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Valid {
+				t.Errorf("expected contrast findings to be warnings only, got %+v", resp.Errors)
+			}
+
+			var got string
+			for _, e := range resp.Errors {
+				if e.Code == "contrast" {
+					got = e.Message
+				}
+			}
+			if got != tt.wantWarning {
+				t.Errorf("expected contrast warning %q, got %q", tt.wantWarning, got)
+			}
+		})
+	}
+}
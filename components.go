@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+)
+
+// MaxPayloadBytes is the Teams message size limit. Component cards that would
+// push a message past it are dropped.
+const MaxPayloadBytes = 28 * 1024
+
+// Component describes the changes to one component of a release.
+type Component struct {
+	Name    string   `json:"name"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// parseComponents reads the components config, an array of {name, changes}
+// objects where changes is a list of strings or a single string.
+func parseComponents(raw any) []Component {
+	items, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+
+	components := make([]Component, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := m["name"].(string)
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		component := Component{Name: name}
+		switch changes := m["changes"].(type) {
+		case string:
+			if changes != "" {
+				component.Changes = []string{changes}
+			}
+		case []any:
+			for _, change := range changes {
+				if s, ok := change.(string); ok && s != "" {
+					component.Changes = append(component.Changes, s)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// buildComponentCard builds the carousel card for a component.
+func buildComponentCard(component Component) AdaptiveCard {
+	body := []AdaptiveElement{
+		{Type: "TextBlock", Text: html.EscapeString(component.Name), Weight: "bolder", Size: "medium"},
+	}
+	if len(component.Changes) > 0 {
+		lines := make([]string, len(component.Changes))
+		for i, change := range component.Changes {
+			lines[i] = "- " + html.EscapeString(change)
+		}
+		body = append(body, AdaptiveElement{Type: "TextBlock", Text: strings.Join(lines, "\n"), Wrap: true})
+	} else {
+		body = append(body, AdaptiveElement{Type: "TextBlock", Text: "No changes listed", IsSubtle: true})
+	}
+	return newAdaptiveCard(body, nil)
+}
+
+// appendComponentCards adds a carousel attachment per component card while
+// the message stays under MaxPayloadBytes, returning how many were dropped.
+func appendComponentCards(msg *TeamsMessage, cards []AdaptiveCard) int {
+	for i, card := range cards {
+		msg.Attachments = append(msg.Attachments, TeamsAttachment{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		})
+		if payload, err := json.Marshal(msg); err != nil || len(payload) > MaxPayloadBytes {
+			msg.Attachments = msg.Attachments[:len(msg.Attachments)-1]
+			return len(cards) - i
+		}
+		msg.AttachmentLayout = "carousel"
+	}
+	return 0
+}
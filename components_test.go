@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestComponentAttachments(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"components": []any{
+				map[string]any{"name": "api", "changes": []any{"add /v2/users", "fix <auth>"}},
+				map[string]any{"name": "web", "changes": "new dashboard"},
+				map[string]any{"name": "worker"},
+			},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	var msg TeamsMessage
+	if err := json.Unmarshal(bodies[0], &msg); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	if len(msg.Attachments) != 4 {
+		t.Fatalf("expected main card plus 3 component cards, got %d", len(msg.Attachments))
+	}
+	if msg.AttachmentLayout != "carousel" {
+		t.Errorf("expected carousel layout, got %q", msg.AttachmentLayout)
+	}
+	if msg.Attachments[0].Content.Body[0].Text != "Release 1.0.0" {
+		t.Errorf("expected the release card first, got %q", msg.Attachments[0].Content.Body[0].Text)
+	}
+
+	api := msg.Attachments[1].Content
+	if api.Body[0].Text != "api" || api.Body[1].Text != "- add /v2/users\n- fix &lt;auth&gt;" {
+		t.Errorf("unexpected api card: %+v", api.Body)
+	}
+	if web := msg.Attachments[2].Content; web.Body[1].Text != "- new dashboard" {
+		t.Errorf("unexpected web card: %+v", web.Body)
+	}
+	if worker := msg.Attachments[3].Content; worker.Body[1].Text != "No changes listed" {
+		t.Errorf("unexpected worker card: %+v", worker.Body)
+	}
+}
+
+func TestComponentAttachmentsPayloadLimit(t *testing.T) {
+	t.Parallel()
+
+	big := strings.Repeat("x", MaxPayloadBytes/3)
+	cards := []AdaptiveCard{
+		buildComponentCard(Component{Name: "a", Changes: []string{big}}),
+		buildComponentCard(Component{Name: "b", Changes: []string{big}}),
+		buildComponentCard(Component{Name: "c", Changes: []string{big}}),
+	}
+
+	msg := (&TeamsPlugin{}).buildTeamsMessage([]AdaptiveElement{{Type: "TextBlock", Text: "Release"}}, nil, nil, "")
+	dropped := appendComponentCards(&msg, cards)
+
+	if dropped != 1 || len(msg.Attachments) != 3 {
+		t.Errorf("expected one card dropped and 3 attachments, got dropped=%d attachments=%d", dropped, len(msg.Attachments))
+	}
+	payload, _ := json.Marshal(msg)
+	if len(payload) > MaxPayloadBytes {
+		t.Errorf("payload of %d bytes exceeds limit", len(payload))
+	}
+}
+
+func TestValidateComponents(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"components":  []any{map[string]any{"name": "api"}, map[string]any{"changes": "orphan"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || len(resp.Errors) != 1 || resp.Errors[0].Message != "components[1].name is required" {
+		t.Errorf("expected missing name error, got %+v", resp.Errors)
+	}
+}
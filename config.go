@@ -0,0 +1,536 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// LoadConfig parses and validates a raw plugin configuration in one pass.
+// The returned Config is always fully populated with defaults; the errors
+// include warnings, which isWarning identifies and which never make the
+// configuration invalid. parseConfig and Validate both delegate to it so
+// the options they read can't drift apart.
+func LoadConfig(raw map[string]any) (*Config, []plugin.ValidationError) {
+	expanded := interpolateConfig(raw)
+	parser := helpers.NewConfigParser(expanded)
+	cfg := buildConfig(parser, expanded)
+	return cfg, validateConfig(cfg, parser, expanded)
+}
+
+// buildConfig reads every option from the interpolated configuration.
+func buildConfig(parser *helpers.ConfigParser, expanded map[string]any) *Config {
+	webhookSources := normalizeWebhookSources(parser.GetStringSlice("webhook_sources", nil))
+	webhookURL, webhookFile := webhookFromSources(parser, webhookSources)
+
+	envDisabled, _ := strconv.ParseBool(os.Getenv(EnvDisabled))
+
+	// Display options default to the style preset's values
+	stylePreset := strings.ToLower(strings.TrimSpace(parser.GetString("style_preset", "", StylePresetStandard)))
+	preset := resolveStylePreset(stylePreset)
+
+	return &Config{
+		Enabled:           parser.GetBool("enabled", true) && !envDisabled,
+		WebhookURL:        webhookURL,
+		WebhookURLs:       parser.GetStringSlice("webhook_urls", nil),
+		ConcurrentFanout:  parser.GetBool("concurrent_fanout", true),
+		FanoutConcurrency: parser.GetInt("fanout_concurrency", DefaultFanoutConcurrency),
+		WebhookURLSuccess: parser.GetString("webhook_url_success", "", ""),
+		WebhookURLError:   parser.GetString("webhook_url_error", "", ""),
+		TitleTemplate:     parser.GetString("title_template", "", DefaultTitleTemplate),
+		StylePreset:       stylePreset,
+		IncludeChangelog:  parser.GetBool("include_changelog", preset.IncludeChangelog),
+		RenderMarkdown:    parser.GetBool("render_markdown", false),
+		ShowSummary:       parser.GetBool("show_summary", preset.ShowSummary),
+		ShowInfo:          parser.GetBool("show_info", preset.ShowInfo),
+		ShowActions:       parser.GetBool("show_actions", preset.ShowActions),
+		ThemeColor:        parser.GetString("theme_color", "", DefaultThemeColor),
+		MentionUsers:      parser.GetStringSlice("mention_users", nil),
+		NotifyOnSuccess:   parser.GetBool("notify_on_success", envBool(EnvNotifyOnSuccess, true)),
+		NotifyOnError:     parser.GetBool("notify_on_error", envBool(EnvNotifyOnError, true)),
+		VerifyHostIP:      parser.GetBool("verify_host_ip", false),
+		GroupedLayout:     parser.GetBool("grouped_layout", false),
+		Layout:            strings.ToLower(strings.TrimSpace(parser.GetString("layout", "", LayoutColumns))),
+		WebhookURLFile:    webhookFile,
+		WebhookSources:    webhookSources,
+		MentionUsersFile:  parser.GetString("mention_users_file", "", ""),
+
+		FanoutSuccessPolicy:     strings.ToLower(parser.GetString("fanout_success_policy", "", FanoutSuccessAll)),
+		InsecureLocalTesting:    parser.GetBool("insecure_local_testing", false) && envBool(EnvAllowInsecure, false),
+		ResultMessageTemplate:   parser.GetString("result_message_template", "", ""),
+		ValidateContrast:        parser.GetBool("validate_contrast", false),
+		TitleMaxChars:           parser.GetInt("title_max_chars", DefaultTitleMaxChars),
+		ConfigResolutionRetries: parser.GetInt("config_resolution_retries", 0),
+		LogsURLTemplate:         parser.GetString("logs_url_template", "", ""),
+		Idempotent:              parser.GetBool("idempotent", false),
+		IdempotencyCacheSize:    parser.GetInt("idempotency_cache_size", DefaultIdempotencyCacheSize),
+		IdempotencyTTLSeconds:   parser.GetInt("idempotency_ttl_seconds", DefaultIdempotencyTTLSeconds),
+		SpoolDir:                parser.GetString("spool_dir", "", ""),
+		CooldownSeconds:         parser.GetInt("cooldown_seconds", 0),
+		CooldownBypassErrors:    parser.GetBool("cooldown_bypass_errors", false),
+		StateFile:               parser.GetString("state_file", "", ""),
+		QuietUnhandled:          parser.GetBool("quiet_unhandled", false),
+		ForceStatus:             strings.ToLower(parser.GetString("force_status", "", "")),
+		StripANSI:               parser.GetBool("strip_ansi", true),
+		PrettyPayload:           parser.GetBool("pretty_payload", false),
+		ChangelogMaxLength:      parser.GetInt("changelog_max_length", DefaultChangelogMaxLength),
+		ReleaseNotesURL:         parser.GetString("release_notes_url", "", ""),
+		AllowedActionHosts:      parser.GetStringSlice("allowed_action_hosts", nil),
+		EmptyChangelogText:      parser.GetString("empty_changelog_text", "", ""),
+		Labels:                  parseLabels(parser.GetMap("labels")),
+		ColorOverrides:          parseColorMap(parser.GetMap("color_overrides")),
+		EnvironmentColors:       parseColorMap(parser.GetMap("environment_colors")),
+		DigestWebhookURL:        parser.GetString("digest_webhook_url", "", ""),
+		ReportSkips:             parser.GetBool("report_skips", false),
+		SkipReportWebhookURL:    parser.GetString("skip_report_webhook_url", "", ""),
+		MinSeverity:             strings.ToLower(parser.GetString("min_severity", "", SeverityInfo)),
+		NotifyOnApproval:        parser.GetBool("notify_on_approval", false),
+		RequestTimeoutSeconds:   parser.GetInt("request_timeout_seconds", DefaultRequestTimeoutSeconds),
+		ConnectTimeoutMS:        parser.GetInt("connect_timeout_ms", 0),
+		ReadTimeoutMS:           parser.GetInt("read_timeout_ms", 0),
+		PinnedCertSHA256:        parser.GetString("pinned_cert_sha256", "", ""),
+		CACertFile:              parser.GetString("ca_cert_file", "", ""),
+		ProxyURL:                parser.GetString("proxy_url", "", ""),
+		Importance:              strings.ToLower(parser.GetString("importance", "", ImportanceNormal)),
+		ExpireAfterSeconds:      parser.GetInt("expire_after_seconds", 0),
+		SuccessIcon:             strings.TrimSpace(parser.GetString("success_icon", "", "")),
+		ErrorIcon:               strings.TrimSpace(parser.GetString("error_icon", "", "")),
+		RedactErrorPatterns:     parser.GetStringSlice("redact_error_patterns", nil),
+		CoalesceErrors:          parser.GetBool("coalesce_errors", false),
+		CoalesceWindowSeconds:   parser.GetInt("coalesce_window_seconds", DefaultCoalesceWindowSeconds),
+		CoalesceMaxErrors:       parser.GetInt("coalesce_max_errors", DefaultCoalesceMaxErrors),
+		BackgroundImageURL:      parser.GetString("background_image_url", "", ""),
+		Language:                strings.TrimSpace(parser.GetString("language", "", "")),
+		RTL:                     parser.GetBool("rtl", false),
+		FooterTimestamp:         parser.GetBool("footer_timestamp", false),
+		Timezone:                strings.TrimSpace(parser.GetString("timezone", "", "")),
+		Components:              parseComponents(expanded["components"]),
+		Stage:                   strings.TrimSpace(parser.GetString("stage", "", "")),
+		Stages:                  parser.GetStringSlice("stages", DefaultStages),
+		MaxActions:              parser.GetInt("max_actions", DefaultMaxActions),
+		ActionsPosition:         strings.ToLower(parser.GetString("actions_position", "", ActionsPositionBottom)),
+		ShowDeprecations:        parser.GetBool("show_deprecations", preset.ShowDeprecations),
+		ShowCardDetails:         parser.GetBool("show_card_details", false),
+		ShowQRCode:              parser.GetBool("show_qr_code", false),
+		UpgradeInstructions:     parser.GetString("upgrade_instructions", "", ""),
+		ExtraFacts:              parseExtraFacts(parser.GetMap("extra_facts")),
+		MaxExtraFacts:           parser.GetInt("max_extra_facts", DefaultMaxExtraFacts),
+		GroupByScope:            parser.GetBool("group_by_scope", preset.GroupByScope),
+		IncludeChangeDetails:    parser.GetBool("include_change_details", false),
+		ChangeDetailsLimit:      parser.GetInt("change_details_limit", DefaultChangeDetailsLimit),
+		CategoryEmoji:           parser.GetBool("category_emoji", preset.CategoryEmoji),
+		LinkifyIssues:           parser.GetBool("linkify_issues", false),
+		ShowContributorCount:    parser.GetBool("show_contributor_count", preset.ShowContributorCount),
+		ShowCIProvider:          parser.GetBool("show_ci_provider", false),
+		ShowPreviousVersion:     parser.GetBool("show_previous_version", preset.ShowPreviousVersion),
+		CIProvider:              strings.TrimSpace(parser.GetString("ci_provider", "", "")),
+		EmbedMetadata:           parser.GetBool("embed_metadata", false),
+		ReleaseTypeBadge:        parser.GetBool("release_type_badge", false),
+		DecoratePrerelease:      parser.GetBool("decorate_prerelease", false),
+		SkipEmptyRelease:        parser.GetBool("skip_empty_release", false),
+		SkipMarker:              parser.GetString("skip_marker", "", DefaultSkipMarker),
+		ResolveMentions:         parser.GetBool("resolve_mentions", false),
+		GraphTenantID:           parser.GetString("graph_tenant_id", EnvGraphTenantID, ""),
+		GraphClientID:           parser.GetString("graph_client_id", EnvGraphClientID, ""),
+		GraphClientSecret:       parser.GetString("graph_client_secret", EnvGraphClientSecret, ""),
+		MaxMentions:             parser.GetInt("max_mentions", 0),
+		ChunkMentions:           parser.GetBool("chunk_mentions", false),
+		MentionOnlyOnBreaking:   parser.GetBool("mention_only_on_breaking", false),
+		ShowApprover:            parser.GetBool("show_approver", false),
+		ApprovedBy:              parser.GetString("approved_by", "", ""),
+		ApprovedAt:              parser.GetString("approved_at", "", ""),
+		RelativeTime:            parser.GetBool("relative_time", false),
+		ReleasedAt:              parser.GetString("released_at", "", ""),
+		SuccessStatusCodes:      parseStatusCodes(expanded["success_status_codes"]),
+		MaxRetries:              parser.GetInt("max_retries", DefaultMaxRetries),
+		RetryBackoffMS:          parser.GetInt("retry_backoff_ms", DefaultRetryBackoffMS),
+		RetryStrategy:           strings.ToLower(parser.GetString("retry_strategy", "", RetryStrategyExponential)),
+	}
+}
+
+// validateConfig checks a built Config. Options whose raw form matters, such
+// as lists that may contain invalid entries, are checked from parser or
+// expanded instead.
+func validateConfig(cfg *Config, parser *helpers.ConfigParser, expanded map[string]any) []plugin.ValidationError {
+	vb := helpers.NewValidationBuilder()
+
+	for _, source := range cfg.WebhookSources {
+		switch source {
+		case WebhookSourceConfig, WebhookSourceFile, WebhookSourceEnv:
+		default:
+			vb.AddErrorWithCode("webhook_sources",
+				fmt.Sprintf("webhook_sources contains unknown source %q (must be config, file or env)", source),
+				"format")
+		}
+	}
+
+	var warnings validationWarnings
+
+	// insecure_local_testing needs TEAMS_ALLOW_INSECURE as a second opt-in
+	switch {
+	case cfg.InsecureLocalTesting:
+		warnings.add("insecure_local_testing",
+			"insecure_local_testing is enabled; localhost webhooks are accepted and TLS 1.2 is allowed, never use it in production",
+			"format")
+	case parser.GetBool("insecure_local_testing", false):
+		warnings.add("insecure_local_testing",
+			fmt.Sprintf("insecure_local_testing is ignored unless %s=true is also set", EnvAllowInsecure),
+			"format")
+	}
+
+	// The webhook URL was resolved in webhook_sources order (config, file, env by default)
+	switch {
+	case cfg.WebhookURL != "":
+		if err := cfg.validateWebhookURL(cfg.WebhookURL); err != nil {
+			vb.AddErrorWithCode("webhook_url", err.Error(), "format")
+		} else if warning := webhookPathWarning(cfg.WebhookURL); warning != "" {
+			warnings.add("webhook_url", warning, "format")
+		}
+	case cfg.WebhookURLFile != "":
+		// The file may be mounted after validation runs, so only check its contents when readable
+		if fileURL, err := readWebhookURLFile(cfg.WebhookURLFile); err == nil {
+			if err := cfg.validateWebhookURL(fileURL); err != nil {
+				vb.AddErrorWithCode("webhook_url_file", err.Error(), "format")
+			} else if warning := webhookPathWarning(fileURL); warning != "" {
+				warnings.add("webhook_url_file", warning, "format")
+			}
+		}
+	case len(cfg.WebhookURLs) > 0:
+		// webhook_urls alone is enough
+	default:
+		vb.AddErrorWithCode("webhook_url",
+			"Teams webhook URL is required (set TEAMS_WEBHOOK_URL env var or configure webhook_url)",
+			"required")
+	}
+
+	for _, routed := range []struct{ key, url string }{
+		{"webhook_url_success", cfg.WebhookURLSuccess},
+		{"webhook_url_error", cfg.WebhookURLError},
+		{"skip_report_webhook_url", cfg.SkipReportWebhookURL},
+	} {
+		if key := routed.key; routed.url != "" {
+			if err := cfg.validateWebhookURL(routed.url); err != nil {
+				vb.AddErrorWithCode(key, err.Error(), "format")
+			} else if warning := webhookPathWarning(routed.url); warning != "" {
+				warnings.add(key, warning, "format")
+			}
+		}
+	}
+
+	if cfg.WebhookURL != "" && len(cfg.WebhookURLs) > 0 {
+		warnings.add("webhook_urls",
+			"both webhook_url and webhook_urls are set; notifications go to every webhook_urls entry, then to webhook_url unless already listed",
+			"precedence")
+	}
+	for i, webhookURL := range cfg.WebhookURLs {
+		if err := cfg.validateWebhookURL(webhookURL); err != nil {
+			vb.AddErrorWithCode("webhook_urls", fmt.Sprintf("webhook_urls[%d]: %s", i, err), "format")
+		} else if warning := webhookPathWarning(webhookURL); warning != "" {
+			warnings.add("webhook_urls", fmt.Sprintf("webhook_urls[%d]: %s", i, warning), "format")
+		}
+	}
+
+	if cfg.FanoutConcurrency < 1 {
+		vb.AddErrorWithCode("fanout_concurrency", "fanout_concurrency must be at least 1", "range")
+	}
+
+	switch cfg.FanoutSuccessPolicy {
+	case FanoutSuccessAll, FanoutSuccessAny:
+	default:
+		vb.AddErrorWithCode("fanout_success_policy", "fanout_success_policy must be one of: all, any", "format")
+	}
+
+	if cfg.DigestWebhookURL != "" {
+		if err := cfg.validateWebhookURL(cfg.DigestWebhookURL); err != nil {
+			vb.AddErrorWithCode("digest_webhook_url", err.Error(), "format")
+		} else if warning := webhookPathWarning(cfg.DigestWebhookURL); warning != "" {
+			warnings.add("digest_webhook_url", warning, "format")
+		}
+	}
+
+	if cfg.PinnedCertSHA256 != "" {
+		if _, err := parseFingerprint(cfg.PinnedCertSHA256); err != nil {
+			vb.AddErrorWithCode("pinned_cert_sha256", err.Error(), "format")
+		}
+	}
+	if cfg.CACertFile != "" {
+		if _, err := loadCACertFile(cfg.CACertFile); err != nil {
+			vb.AddErrorWithCode("ca_cert_file", err.Error(), "format")
+		}
+	}
+	if cfg.ProxyURL != "" {
+		if _, err := parseProxyURL(cfg.ProxyURL); err != nil {
+			vb.AddErrorWithCode("proxy_url", err.Error(), "format")
+		}
+	}
+	// The proxy resolves and connects to the webhook host
+	if verifyHostIPDisabledByProxy(cfg) {
+		warnings.add("verify_host_ip", "verify_host_ip has no effect through proxy_url or an HTTPS_PROXY environment proxy; the proxy connects to the webhook host", "format")
+	}
+
+	switch strings.ToLower(parser.GetString("env_undefined", "", EnvUndefinedEmpty)) {
+	case EnvUndefinedEmpty, EnvUndefinedLiteral:
+	default:
+		vb.AddErrorWithCode("env_undefined", "env_undefined must be one of: empty, literal", "format")
+	}
+
+	if cfg.Language != "" && !languageTagPattern.MatchString(cfg.Language) {
+		vb.AddErrorWithCode("language", "language must be a BCP 47 language tag (e.g., 'en' or 'de-DE')", "format")
+	} else if isRTLLanguage(cfg.Language) && !cfg.RTL {
+		warnings.add("rtl", fmt.Sprintf("language %q is written right-to-left; set rtl to true to lay the card out right-to-left", cfg.Language), "format")
+	}
+
+	if cfg.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.Timezone); err != nil {
+			vb.AddErrorWithCode("timezone", "timezone must be an IANA timezone name (e.g., 'Europe/Berlin')", "format")
+		}
+	}
+
+	validateRedactPatterns(vb, cfg.RedactErrorPatterns)
+
+	if rawComponents, ok := expanded["components"].([]any); ok {
+		for i, item := range rawComponents {
+			m, _ := item.(map[string]any)
+			if name, _ := m["name"].(string); strings.TrimSpace(name) == "" {
+				vb.AddErrorWithCode("components", fmt.Sprintf("components[%d].name is required", i), "required")
+			}
+		}
+	}
+
+	validateStage(vb, cfg.Stage, cfg.Stages)
+
+	if cfg.CoalesceWindowSeconds < 1 {
+		vb.AddErrorWithCode("coalesce_window_seconds", "coalesce_window_seconds must be at least 1", "range")
+	}
+	if cfg.CoalesceMaxErrors < 1 || cfg.CoalesceMaxErrors > MaxCoalesceMaxErrors {
+		vb.AddErrorWithCode("coalesce_max_errors",
+			fmt.Sprintf("coalesce_max_errors must be between 1 and %d", MaxCoalesceMaxErrors), "range")
+	}
+
+	if cfg.ChangeDetailsLimit < 1 || cfg.ChangeDetailsLimit > MaxChangeDetailsLimit {
+		vb.AddErrorWithCode("change_details_limit",
+			fmt.Sprintf("change_details_limit must be between 1 and %d", MaxChangeDetailsLimit), "range")
+	}
+	if cfg.IncludeChangeDetails && cfg.GroupByScope {
+		warnings.add("include_change_details",
+			"group_by_scope already lists every change; include_change_details is ignored", "precedence")
+	}
+
+	if cfg.MaxExtraFacts < 0 {
+		vb.AddErrorWithCode("max_extra_facts", "max_extra_facts must not be negative", "range")
+	}
+
+	if cfg.MaxActions < 0 {
+		vb.AddErrorWithCode("max_actions", "max_actions must not be negative", "range")
+	}
+
+	if _, ok := stylePresets[cfg.StylePreset]; !ok {
+		vb.AddErrorWithCode("style_preset", "style_preset must be one of: detailed, standard, compact, minimal", "format")
+	}
+
+	switch cfg.ActionsPosition {
+	case ActionsPositionBottom, ActionsPositionTop:
+	default:
+		vb.AddErrorWithCode("actions_position", "actions_position must be one of: bottom, top", "format")
+	}
+
+	if cfg.MaxMentions < 0 {
+		vb.AddErrorWithCode("max_mentions", "max_mentions must not be negative", "range")
+	}
+
+	if cfg.ConfigResolutionRetries < 0 || cfg.ConfigResolutionRetries > MaxConfigResolutionRetries {
+		vb.AddErrorWithCode("config_resolution_retries",
+			fmt.Sprintf("config_resolution_retries must be between 0 and %d", MaxConfigResolutionRetries),
+			"range")
+	}
+
+	if raw, ok := expanded["success_status_codes"].([]any); ok {
+		codes := parseStatusCodes(raw)
+		if len(codes) != len(raw) {
+			vb.AddErrorWithCode("success_status_codes", "success_status_codes must be a list of HTTP status codes", "format")
+		}
+		for _, code := range codes {
+			if code < 200 || code > 299 {
+				vb.AddErrorWithCode("success_status_codes", fmt.Sprintf("success_status_codes must be 2xx statuses, got %d", code), "range")
+			}
+		}
+	}
+
+	for _, timeout := range []struct {
+		key string
+		ms  int
+	}{
+		{"connect_timeout_ms", cfg.ConnectTimeoutMS},
+		{"read_timeout_ms", cfg.ReadTimeoutMS},
+	} {
+		if key := timeout.key; timeout.ms < 0 || timeout.ms > MaxTimeoutMS {
+			vb.AddErrorWithCode(key, fmt.Sprintf("%s must be between 0 and %d", key, MaxTimeoutMS), "range")
+		}
+	}
+
+	if cfg.RequestTimeoutSeconds < MinRequestTimeoutSeconds || cfg.RequestTimeoutSeconds > MaxRequestTimeoutSeconds {
+		vb.AddErrorWithCode("request_timeout_seconds",
+			fmt.Sprintf("request_timeout_seconds must be between %d and %d", MinRequestTimeoutSeconds, MaxRequestTimeoutSeconds), "range")
+	}
+	if cfg.MaxRetries < 0 || cfg.MaxRetries > MaxRetries {
+		vb.AddErrorWithCode("max_retries", fmt.Sprintf("max_retries must be between 0 and %d", MaxRetries), "range")
+	}
+	if cfg.RetryBackoffMS < 0 || cfg.RetryBackoffMS > MaxRetryBackoffMS {
+		vb.AddErrorWithCode("retry_backoff_ms", fmt.Sprintf("retry_backoff_ms must be between 0 and %d", MaxRetryBackoffMS), "range")
+	}
+	switch cfg.RetryStrategy {
+	case RetryStrategyExponential, RetryStrategyFixed:
+	default:
+		vb.AddErrorWithCode("retry_strategy", "retry_strategy must be one of: exponential, fixed", "format")
+	}
+
+	if cfg.ExpireAfterSeconds < 0 {
+		vb.AddErrorWithCode("expire_after_seconds", "expire_after_seconds must not be negative", "range")
+	}
+
+	switch cfg.Layout {
+	case LayoutColumns, LayoutFacts:
+	default:
+		vb.AddErrorWithCode("layout", "layout must be one of: columns, facts", "format")
+	}
+
+	switch cfg.Importance {
+	case ImportanceNormal, ImportanceHigh, ImportanceUrgent:
+	default:
+		vb.AddErrorWithCode("importance", "importance must be one of: normal, high, urgent", "format")
+	}
+
+	if _, ok := severityRank[cfg.MinSeverity]; !ok {
+		vb.AddErrorWithCode("min_severity", "min_severity must be one of: info, warning, error", "format")
+	}
+
+	switch cfg.ForceStatus {
+	case "", StatusSuccess, StatusError:
+	default:
+		vb.AddErrorWithCode("force_status", "force_status must be one of: success, error", "format")
+	}
+
+	if _, err := renderTemplate(cfg.TitleTemplate, samplePlaceholderContext); err != nil {
+		vb.AddErrorWithCode("title_template", err.Error(), "format")
+	}
+	if cfg.TitleMaxChars < 1 {
+		vb.AddErrorWithCode("title_max_chars", "title_max_chars must be at least 1", "range")
+	}
+	sampleResult := newResultMessageData(StatusSuccess, 1, samplePlaceholderContext)
+	if _, err := executeTemplate("result_message", cfg.ResultMessageTemplate, sampleResult); err != nil {
+		vb.AddErrorWithCode("result_message_template", err.Error(), "format")
+	}
+	if _, err := renderUpgradeInstructions(cfg.UpgradeInstructions, samplePlaceholderContext, cfg.RenderMarkdown); err != nil {
+		vb.AddErrorWithCode("upgrade_instructions", err.Error(), "format")
+	}
+
+	validateAllowedActionHosts(vb, cfg.AllowedActionHosts)
+
+	if cfg.LogsURLTemplate != "" {
+		if logsURL, err := executeTemplate("logs_url", cfg.LogsURLTemplate, newTemplateData(samplePlaceholderContext)); err != nil {
+			vb.AddErrorWithCode("logs_url_template", err.Error(), "format")
+		} else if err := validateHTTPSURL(logsURL); err != nil {
+			vb.AddErrorWithCode("logs_url_template", err.Error(), "format")
+		} else if err := validateActionHost(logsURL, cfg.AllowedActionHosts); err != nil {
+			vb.AddErrorWithCode("logs_url_template", err.Error(), "format")
+		}
+	}
+
+	if cfg.BackgroundImageURL != "" {
+		if err := validateHTTPSURL(cfg.BackgroundImageURL); err != nil {
+			vb.AddErrorWithCode("background_image_url", err.Error(), "format")
+		}
+	}
+
+	if cfg.ChangelogMaxLength < 1 || cfg.ChangelogMaxLength > MaxChangelogMaxLength {
+		vb.AddErrorWithCode("changelog_max_length",
+			fmt.Sprintf("changelog_max_length must be between 1 and %d", MaxChangelogMaxLength), "range")
+	}
+	if cfg.ReleaseNotesURL != "" {
+		if err := validateHTTPSURL(cfg.ReleaseNotesURL); err != nil {
+			vb.AddErrorWithCode("release_notes_url", err.Error(), "format")
+		} else if err := validateActionHost(cfg.ReleaseNotesURL, cfg.AllowedActionHosts); err != nil {
+			vb.AddErrorWithCode("release_notes_url", err.Error(), "format")
+		}
+	}
+
+	for _, icon := range []struct{ key, value string }{
+		{"success_icon", cfg.SuccessIcon},
+		{"error_icon", cfg.ErrorIcon},
+	} {
+		if key := icon.key; utf8.RuneCountInString(icon.value) > MaxIconLength {
+			vb.AddErrorWithCode(key, fmt.Sprintf("%s must be at most %d characters", key, MaxIconLength), "range")
+		}
+	}
+
+	if _, dropped := sanitizeMentions(cfg.MentionUsers); len(dropped) > 0 {
+		vb.AddErrorWithCode("mention_users",
+			fmt.Sprintf("mention_users contains invalid entries (markup or control characters): %q", dropped),
+			"format")
+	}
+
+	if cfg.IdempotencyCacheSize < 1 {
+		vb.AddErrorWithCode("idempotency_cache_size", "idempotency_cache_size must be at least 1", "range")
+	}
+	if cfg.IdempotencyTTLSeconds < 1 {
+		vb.AddErrorWithCode("idempotency_ttl_seconds", "idempotency_ttl_seconds must be at least 1", "range")
+	}
+
+	if cfg.ReleasedAt != "" {
+		if _, err := time.Parse(time.RFC3339, strings.TrimSpace(cfg.ReleasedAt)); err != nil {
+			vb.AddErrorWithCode("released_at", "released_at must be an RFC 3339 timestamp (e.g., 2024-01-02T15:04:05Z)", "format")
+		}
+	}
+
+	if cfg.SpoolDir != "" && !filepath.IsAbs(cfg.SpoolDir) {
+		vb.AddErrorWithCode("spool_dir", "spool_dir must be an absolute path", "format")
+	}
+	if cfg.CooldownSeconds < 0 {
+		vb.AddErrorWithCode("cooldown_seconds", "cooldown_seconds must not be negative", "range")
+	}
+	if cfg.StateFile != "" && !filepath.IsAbs(cfg.StateFile) {
+		vb.AddErrorWithCode("state_file", "state_file must be an absolute path", "format")
+	}
+
+	validateRequirements(parser, vb)
+
+	validateLabels(vb, parser.GetMap("labels"))
+	validateColorMap(vb, "color_overrides", parser.GetMap("color_overrides"), colorOverrideKinds)
+	validateColorMap(vb, "environment_colors", parser.GetMap("environment_colors"), nil)
+
+	// Validate theme_color if provided
+	themeColor := parser.GetString("theme_color", "", "")
+	if themeColor != "" {
+		// Remove # if present
+		themeColor = strings.TrimPrefix(themeColor, "#")
+		if len(themeColor) != 6 {
+			vb.AddErrorWithCode("theme_color", "theme_color must be a 6-character hex color (e.g., '0076D7')", "format")
+		} else {
+			// Check if valid hex
+			for _, c := range themeColor {
+				isDigit := c >= '0' && c <= '9'
+				isLowerHex := c >= 'a' && c <= 'f'
+				isUpperHex := c >= 'A' && c <= 'F'
+				if !isDigit && !isLowerHex && !isUpperHex {
+					vb.AddErrorWithCode("theme_color", "theme_color must contain only hexadecimal characters", "format")
+					break
+				}
+			}
+		}
+	}
+
+	if cfg.ValidateContrast && isHexColor(cfg.ThemeColor) {
+		if warning := contrastWarning("theme_color", cfg.ThemeColor); warning != "" {
+			warnings.add("theme_color", warning, "contrast")
+		}
+	}
+
+	return warnings.apply(vb.Build()).Errors
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestLoadConfigDefaults(t *testing.T) {
+	t.Parallel()
+
+	cfg, errs := LoadConfig(map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+	})
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %+v", errs)
+	}
+	if !cfg.Enabled || !cfg.IncludeChangelog {
+		t.Errorf("expected enabled defaults, got %+v", cfg)
+	}
+	if cfg.TitleTemplate != DefaultTitleTemplate {
+		t.Errorf("expected default title template, got %q", cfg.TitleTemplate)
+	}
+	if cfg.MaxActions != DefaultMaxActions || cfg.RetryBackoffMS != DefaultRetryBackoffMS {
+		t.Errorf("expected numeric defaults, got max_actions=%d retry_backoff_ms=%d", cfg.MaxActions, cfg.RetryBackoffMS)
+	}
+}
+
+func TestLoadConfigReturnsConfigWithErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg, errs := LoadConfig(map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"max_retries": -1,
+		"importance":  "critical",
+	})
+	if cfg == nil {
+		t.Fatal("expected a config even when validation fails")
+	}
+	if cfg.MaxRetries != -1 || cfg.Importance != "critical" {
+		t.Errorf("expected the invalid values to be parsed as given, got max_retries=%d importance=%q", cfg.MaxRetries, cfg.Importance)
+	}
+
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, field := range []string{"max_retries", "importance"} {
+		if !fields[field] {
+			t.Errorf("expected an error for %s, got %+v", field, errs)
+		}
+	}
+}
+
+func TestLoadConfigMatchesParseAndValidate(t *testing.T) {
+	t.Parallel()
+
+	configs := map[string]map[string]any{
+		"empty": {},
+		"valid": {
+			"webhook_url":        "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"webhook_url_error":  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/abc",
+			"mention_users":      []any{"alice@example.com"},
+			"connect_timeout_ms": 2000,
+			"language":           " de-DE ",
+		},
+		"invalid": {
+			"webhook_url":       "http://example.com/hook",
+			"webhook_url_error": "not a url",
+			"read_timeout_ms":   MaxTimeoutMS + 1,
+			"success_icon":      "far too long for an icon",
+			"timezone":          "Mars/Olympus",
+			"retry_strategy":    "random",
+		},
+	}
+
+	p := &TeamsPlugin{}
+	for name, raw := range configs {
+		cfg, errs := LoadConfig(raw)
+
+		if parsed := p.parseConfig(raw); !reflect.DeepEqual(parsed, cfg) {
+			t.Errorf("%s: parseConfig differs from LoadConfig:\n%+v\n%+v", name, parsed, cfg)
+		}
+
+		resp, err := p.Validate(context.Background(), raw)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if !reflect.DeepEqual(resp.Errors, errs) {
+			t.Errorf("%s: Validate errors differ from LoadConfig:\n%+v\n%+v", name, resp.Errors, errs)
+		}
+		if wantValid := name == "valid"; resp.Valid != wantValid {
+			t.Errorf("%s: expected Valid=%v, got %+v", name, wantValid, resp.Errors)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// configResolutionRetryDelay is the pause between config resolution attempts.
+const configResolutionRetryDelay = 2 * time.Second
+
+// MaxConfigResolutionRetries bounds config_resolution_retries.
+const MaxConfigResolutionRetries = 10
+
+// Webhook URL sources accepted by webhook_sources.
+const (
+	WebhookSourceConfig = "config"
+	WebhookSourceFile   = "file"
+	WebhookSourceEnv    = "env"
+)
+
+// EnvWebhookURL is the environment variable read by the env webhook source.
+const EnvWebhookURL = "TEAMS_WEBHOOK_URL"
+
+// DefaultWebhookSources is the default webhook resolution order.
+var DefaultWebhookSources = []string{WebhookSourceConfig, WebhookSourceFile, WebhookSourceEnv}
+
+// normalizeWebhookSources lowercases and trims the configured sources,
+// returning DefaultWebhookSources when none are set.
+func normalizeWebhookSources(sources []string) []string {
+	normalized := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if source = strings.ToLower(strings.TrimSpace(source)); source != "" {
+			normalized = append(normalized, source)
+		}
+	}
+	if len(normalized) == 0 {
+		return DefaultWebhookSources
+	}
+	return normalized
+}
+
+// webhookFromSources walks sources in order and returns the first configured
+// webhook URL. When the file source wins, the URL is empty and webhookFile is
+// returned instead so it can be read during config resolution.
+func webhookFromSources(parser *helpers.ConfigParser, sources []string) (webhookURL, webhookFile string) {
+	for _, source := range sources {
+		switch source {
+		case WebhookSourceConfig:
+			if v := parser.GetString("webhook_url", "", ""); v != "" {
+				return v, ""
+			}
+		case WebhookSourceFile:
+			if v := parser.GetString("webhook_url_file", "", ""); v != "" {
+				return "", v
+			}
+		case WebhookSourceEnv:
+			if v := os.Getenv(EnvWebhookURL); v != "" {
+				return v, ""
+			}
+		}
+	}
+	return "", ""
+}
+
+// resolveConfig loads file-backed config values, retrying up to
+// cfg.ConfigResolutionRetries times, then drops invalid mentions. Secret
+// volumes in Kubernetes are not always mounted by the time the release job starts.
+func (p *TeamsPlugin) resolveConfig(ctx context.Context, cfg *Config) error {
+	var err error
+	for attempt := 0; attempt <= cfg.ConfigResolutionRetries; attempt++ {
+		if attempt > 0 {
+			p.getLogger().Warn("retrying config resolution", "attempt", attempt+1, "error", err.Error())
+			if sleepErr := p.sleep(ctx, configResolutionRetryDelay); sleepErr != nil {
+				return sleepErr
+			}
+		}
+		if err = resolveConfigFiles(cfg); err == nil {
+			p.sanitizeConfigMentions(cfg)
+			return nil
+		}
+	}
+	return err
+}
+
+// resolveConfigFiles reads webhook_url_file and mention_users_file into cfg.
+// The webhook file is only read when it won the webhook_sources order.
+func resolveConfigFiles(cfg *Config) error {
+	if cfg.WebhookURL == "" && cfg.WebhookURLFile != "" {
+		webhookURL, err := readWebhookURLFile(cfg.WebhookURLFile)
+		if err != nil {
+			return err
+		}
+		cfg.WebhookURL = webhookURL
+	}
+
+	if cfg.MentionUsersFile != "" {
+		users, err := readMentionUsersFile(cfg.MentionUsersFile)
+		if err != nil {
+			return err
+		}
+		cfg.MentionUsers = appendUnique(cfg.MentionUsers, users...)
+		cfg.MentionUsersFile = ""
+	}
+	return nil
+}
+
+// readWebhookURLFile reads a webhook URL from a file, trimming whitespace.
+func readWebhookURLFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read webhook_url_file: %w", err)
+	}
+	webhookURL := strings.TrimSpace(string(data))
+	if webhookURL == "" {
+		return "", fmt.Errorf("webhook_url_file %s is empty", path)
+	}
+	return webhookURL, nil
+}
+
+// readMentionUsersFile reads one user per line, skipping blanks and # comments.
+func readMentionUsersFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mention_users_file: %w", err)
+	}
+
+	var users []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		users = append(users, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mention_users_file: %w", err)
+	}
+	return users, nil
+}
+
+// appendUnique appends values not already present in dst.
+func appendUnique(dst []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range dst {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestResolveConfigFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	webhookFile := filepath.Join(dir, "webhook")
+	mentionsFile := filepath.Join(dir, "mentions")
+	if err := os.WriteFile(webhookFile, []byte("  https://example.webhook.office.com/webhookb2/file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mentionsFile, []byte("# on-call\na@example.com\n\nb@example.com\na@example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("reads_files", func(t *testing.T) {
+		cfg := &Config{
+			WebhookURLFile:   webhookFile,
+			MentionUsersFile: mentionsFile,
+			MentionUsers:     []string{"b@example.com"},
+		}
+		if err := resolveConfigFiles(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.WebhookURL != "https://example.webhook.office.com/webhookb2/file" {
+			t.Errorf("unexpected webhook URL %q", cfg.WebhookURL)
+		}
+		want := []string{"b@example.com", "a@example.com"}
+		if strings.Join(cfg.MentionUsers, ",") != strings.Join(want, ",") {
+			t.Errorf("expected mentions %v, got %v", want, cfg.MentionUsers)
+		}
+	})
+
+	t.Run("explicit_webhook_wins", func(t *testing.T) {
+		cfg := &Config{
+			WebhookURL:     "https://example.webhook.office.com/webhookb2/config",
+			WebhookURLFile: filepath.Join(dir, "missing"),
+		}
+		if err := resolveConfigFiles(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.WebhookURL != "https://example.webhook.office.com/webhookb2/config" {
+			t.Errorf("unexpected webhook URL %q", cfg.WebhookURL)
+		}
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		cfg := &Config{WebhookURLFile: filepath.Join(dir, "missing")}
+		err := resolveConfigFiles(cfg)
+		if err == nil || !strings.Contains(err.Error(), "webhook_url_file") {
+			t.Errorf("expected webhook_url_file error, got %v", err)
+		}
+	})
+}
+
+func TestResolveConfigRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		retries      int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "readable_on_second_attempt",
+			retries:      2,
+			wantErr:      false,
+			wantAttempts: 1,
+		},
+		{
+			name:         "no_retry_by_default",
+			retries:      0,
+			wantErr:      true,
+			wantAttempts: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookFile := filepath.Join(t.TempDir(), "webhook")
+
+			var sleeps []time.Duration
+			p := &TeamsPlugin{
+				// The secret "mounts" while the plugin waits to retry.
+				sleepFunc: func(_ context.Context, d time.Duration) error {
+					sleeps = append(sleeps, d)
+					return os.WriteFile(webhookFile, []byte("https://example.webhook.office.com/webhookb2/late"), 0o600)
+				},
+			}
+
+			cfg := &Config{WebhookURLFile: webhookFile, ConfigResolutionRetries: tt.retries}
+			err := p.resolveConfig(context.Background(), cfg)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if len(sleeps) != tt.wantAttempts {
+				t.Errorf("expected %d retries, got %d", tt.wantAttempts, len(sleeps))
+			}
+			if !tt.wantErr && cfg.WebhookURL != "https://example.webhook.office.com/webhookb2/late" {
+				t.Errorf("unexpected webhook URL %q", cfg.WebhookURL)
+			}
+		})
+	}
+}
+
+func TestExecuteConfigResolutionFailure(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url_file": filepath.Join(t.TempDir(), "missing"),
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Error("expected failure when webhook_url_file is unreadable")
+	}
+	if !strings.Contains(resp.Error, "failed to resolve config") {
+		t.Errorf("unexpected error %q", resp.Error)
+	}
+}
+
+func TestValidateWebhookURLFile(t *testing.T) {
+	t.Setenv("TEAMS_WEBHOOK_URL", "")
+
+	dir := t.TempDir()
+	badFile := filepath.Join(dir, "bad")
+	if err := os.WriteFile(badFile, []byte("https://evil.example.com/hook"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantValid bool
+		wantField string
+	}{
+		{
+			name:      "unmounted_file_is_deferred",
+			config:    map[string]any{"webhook_url_file": filepath.Join(dir, "missing")},
+			wantValid: true,
+		},
+		{
+			name:      "invalid_url_in_file",
+			config:    map[string]any{"webhook_url_file": badFile},
+			wantValid: false,
+			wantField: "webhook_url_file",
+		},
+		{
+			name: "retries_out_of_range",
+			config: map[string]any{
+				"webhook_url":               "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				"config_resolution_retries": 11,
+			},
+			wantValid: false,
+			wantField: "config_resolution_retries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &TeamsPlugin{}
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Errorf("expected Valid=%v, got %v (%+v)", tt.wantValid, resp.Valid, resp.Errors)
+			}
+			if tt.wantField != "" && (len(resp.Errors) == 0 || resp.Errors[0].Field != tt.wantField) {
+				t.Errorf("expected error on %q, got %+v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestWebhookSources(t *testing.T) {
+	const (
+		configURL = "https://example.webhook.office.com/webhookb2/config"
+		fileURL   = "https://example.webhook.office.com/webhookb2/file"
+		envURL    = "https://example.webhook.office.com/webhookb2/env"
+	)
+	t.Setenv(EnvWebhookURL, envURL)
+
+	webhookFile := filepath.Join(t.TempDir(), "webhook")
+	if err := os.WriteFile(webhookFile, []byte(fileURL), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantURL string
+	}{
+		{
+			name:    "default_prefers_config",
+			config:  map[string]any{"webhook_url": configURL, "webhook_url_file": webhookFile},
+			wantURL: configURL,
+		},
+		{
+			name:    "default_prefers_file_over_env",
+			config:  map[string]any{"webhook_url_file": webhookFile},
+			wantURL: fileURL,
+		},
+		{
+			name:    "default_falls_back_to_env",
+			config:  map[string]any{},
+			wantURL: envURL,
+		},
+		{
+			name: "file_before_config",
+			config: map[string]any{
+				"webhook_url":      configURL,
+				"webhook_url_file": webhookFile,
+				"webhook_sources":  []any{"file", "config", "env"},
+			},
+			wantURL: fileURL,
+		},
+		{
+			name: "env_first",
+			config: map[string]any{
+				"webhook_url":      configURL,
+				"webhook_url_file": webhookFile,
+				"webhook_sources":  []any{"ENV", "config"},
+			},
+			wantURL: envURL,
+		},
+		{
+			name: "env_excluded",
+			config: map[string]any{
+				"webhook_sources": []any{"config", "file"},
+			},
+			wantURL: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &TeamsPlugin{}
+			cfg := p.parseConfig(tt.config)
+			if err := p.resolveConfig(context.Background(), cfg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.WebhookURL != tt.wantURL {
+				t.Errorf("expected webhook %q, got %q", tt.wantURL, cfg.WebhookURL)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookSources(t *testing.T) {
+	t.Setenv(EnvWebhookURL, "")
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url":     "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"webhook_sources": []any{"config", "vault"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || len(resp.Errors) != 1 || resp.Errors[0].Field != "webhook_sources" {
+		t.Errorf("expected a single webhook_sources error, got %+v", resp.Errors)
+	}
+
+	// Env-only sources must not accept a webhook_url from config
+	resp, err = p.Validate(context.Background(), map[string]any{
+		"webhook_url":     "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"webhook_sources": []any{"env"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || resp.Errors[0].Field != "webhook_url" || resp.Errors[0].Code != "required" {
+		t.Errorf("expected webhook_url required error, got %+v", resp.Errors)
+	}
+}
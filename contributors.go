@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// countContributors returns the number of unique commit authors across all
+// change categories. Authors are deduplicated by email when the author is in
+// "Name <email>" form, and by name otherwise. Commits without author data
+// are ignored.
+func countContributors(changes *plugin.CategorizedChanges) int {
+	if changes == nil {
+		return 0
+	}
+
+	seen := make(map[string]struct{})
+	for _, commits := range [][]plugin.ConventionalCommit{
+		changes.Features,
+		changes.Fixes,
+		changes.Breaking,
+		changes.Performance,
+		changes.Refactor,
+		changes.Docs,
+		changes.Other,
+	} {
+		for _, commit := range commits {
+			if key := contributorKey(commit.Author); key != "" {
+				seen[key] = struct{}{}
+			}
+		}
+	}
+	return len(seen)
+}
+
+// contributorKey normalizes an author to the key used for deduplication.
+func contributorKey(author string) string {
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return ""
+	}
+	if addr, err := mail.ParseAddress(author); err == nil {
+		return strings.ToLower(addr.Address)
+	}
+	return strings.ToLower(author)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCountContributors(t *testing.T) {
+	t.Parallel()
+
+	changes := &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{
+			{Author: "Jane Doe <jane@example.com>"},
+			{Author: "Bob <bob@example.com>"},
+		},
+		Fixes: []plugin.ConventionalCommit{
+			{Author: "J. Doe <JANE@example.com>"},
+			{Author: "ci-bot"},
+			{Author: ""},
+		},
+		Other: []plugin.ConventionalCommit{
+			{Author: "CI-Bot"},
+		},
+	}
+	if got := countContributors(changes); got != 3 {
+		t.Errorf("expected 3 unique contributors, got %d", got)
+	}
+	if got := countContributors(nil); got != 0 {
+		t.Errorf("expected 0 contributors without changes, got %d", got)
+	}
+}
+
+func TestShowContributorCount(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version: "1.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{
+				{Author: "Jane Doe <jane@example.com>"},
+				{Author: "Jane Doe <jane@example.com>"},
+			},
+			Fixes: []plugin.ConventionalCommit{{Author: "Bob <bob@example.com>"}},
+		},
+	}
+
+	n := (&TeamsPlugin{}).buildSuccessNotification(&Config{ShowInfo: true, ShowContributorCount: true}, releaseCtx)
+	if got := infoFacts(n.body)["Contributors:"]; got != "👥 2 contributors" {
+		t.Errorf("unexpected contributors fact: %q", got)
+	}
+
+	// Without author data the fact is omitted
+	releaseCtx.Changes = &plugin.CategorizedChanges{Features: []plugin.ConventionalCommit{{Description: "a"}}}
+	n = (&TeamsPlugin{}).buildSuccessNotification(&Config{ShowInfo: true, ShowContributorCount: true}, releaseCtx)
+	if _, ok := infoFacts(n.body)["Contributors:"]; ok {
+		t.Error("expected no contributors fact without author data")
+	}
+}
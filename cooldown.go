@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// cooldownState records when each webhook was last notified. Webhooks are
+// keyed by hash, so the state file never contains webhook secrets.
+type cooldownState struct {
+	mu   sync.Mutex
+	path string
+	sent map[string]time.Time
+}
+
+// defaultCooldownState is used without a state_file, so cooldowns apply
+// across hook invocations in one process.
+var defaultCooldownState = &cooldownState{sent: make(map[string]time.Time)}
+
+// cooldownKey identifies a webhook in the cooldown state.
+func cooldownKey(webhookURL string) string {
+	sum := sha256.Sum256([]byte(webhookURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadCooldownState reads the cooldown state from path. A missing file is
+// an empty state.
+func loadCooldownState(path string) (*cooldownState, error) {
+	state := &cooldownState{path: path, sent: make(map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, fmt.Errorf("failed to read state_file: %w", err)
+	}
+	if err := json.Unmarshal(data, &state.sent); err != nil {
+		return state, fmt.Errorf("failed to parse state_file: %w", err)
+	}
+	return state, nil
+}
+
+// getCooldownState returns the cooldown state for cfg. A state file that
+// cannot be read is logged and replaced, rather than blocking notifications.
+func (p *TeamsPlugin) getCooldownState(cfg *Config) *cooldownState {
+	if cfg.StateFile == "" {
+		return defaultCooldownState
+	}
+	state, err := loadCooldownState(cfg.StateFile)
+	if err != nil {
+		p.getLogger().Warn("cooldown state reset", "error", err.Error())
+	}
+	return state
+}
+
+// within reports whether webhookURL was notified less than window before now.
+func (s *cooldownState) within(webhookURL string, now time.Time, window time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last, ok := s.sent[cooldownKey(webhookURL)]
+	return ok && now.Sub(last) < window
+}
+
+// record notes that webhookURL was notified at now.
+func (s *cooldownState) record(webhookURL string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[cooldownKey(webhookURL)] = now.UTC()
+}
+
+// save writes the state to its file, dropping entries older than window.
+// It does nothing for the in-process state.
+func (s *cooldownState) save(now time.Time, window time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.path == "" {
+		return nil
+	}
+	for key, last := range s.sent {
+		if now.Sub(last) >= window {
+			delete(s.sent, key)
+		}
+	}
+
+	data, err := json.Marshal(s.sent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cooldown state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create state_file directory: %w", err)
+	}
+
+	// Write then rename so a concurrent invocation never reads a partial file
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state_file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write state_file: %w", err)
+	}
+	return nil
+}
+
+// cooldownApplies reports whether a notification with the given status can
+// be suppressed by cooldown_seconds.
+func (cfg *Config) cooldownApplies(status string) bool {
+	return status != StatusError || !cfg.CooldownBypassErrors
+}
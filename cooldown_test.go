@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCooldown(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+	start := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		hook         plugin.Hook
+		bypassErrors bool
+		elapsed      time.Duration
+		wantSent     bool
+	}{
+		{name: "within_cooldown", hook: plugin.HookPostPublish, elapsed: 30 * time.Second},
+		{name: "after_cooldown", hook: plugin.HookPostPublish, elapsed: 61 * time.Second, wantSent: true},
+		{name: "error_within_cooldown", hook: plugin.HookOnError, elapsed: 30 * time.Second},
+		{name: "error_bypasses_cooldown", hook: plugin.HookOnError, bypassErrors: true, elapsed: 30 * time.Second, wantSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stateFile := filepath.Join(t.TempDir(), "teams-state.json")
+			config := map[string]any{
+				"webhook_url":            webhook,
+				"cooldown_seconds":       60,
+				"cooldown_bypass_errors": tt.bypassErrors,
+				"state_file":             stateFile,
+			}
+
+			// Each invocation uses a fresh plugin, as separate hook runs would
+			execute := func(hook plugin.Hook, now time.Time) (*plugin.ExecuteResponse, int) {
+				var bodies [][]byte
+				p := &TeamsPlugin{
+					httpClient: recordingClient(&bodies),
+					now:        func() time.Time { return now },
+				}
+				resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+					Hook:    hook,
+					Config:  config,
+					Context: plugin.ReleaseContext{Version: "1.0.0"},
+				})
+				if err != nil || !resp.Success {
+					t.Fatalf("unexpected failure: %v %+v", err, resp)
+				}
+				return resp, len(bodies)
+			}
+
+			if _, sent := execute(tt.hook, start); sent != 1 {
+				t.Fatalf("expected the first notification to be sent, got %d requests", sent)
+			}
+			state, err := os.ReadFile(stateFile)
+			if err != nil {
+				t.Fatalf("expected cooldown state to be saved: %v", err)
+			}
+			if strings.Contains(string(state), "webhookb2") {
+				t.Errorf("expected the state file not to contain the webhook URL, got %s", state)
+			}
+
+			resp, sent := execute(tt.hook, start.Add(tt.elapsed))
+			if (sent == 1) != tt.wantSent {
+				t.Errorf("expected sent=%v, got %d requests", tt.wantSent, sent)
+			}
+			if !tt.wantSent && resp.Message != "Within cooldown" {
+				t.Errorf("expected message %q, got %q", "Within cooldown", resp.Message)
+			}
+		})
+	}
+}
+
+func TestCooldownPerWebhook(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	state := &cooldownState{sent: make(map[string]time.Time)}
+	state.record("https://a.example/hook", now)
+
+	if !state.within("https://a.example/hook", now.Add(time.Minute), time.Hour) {
+		t.Error("expected the notified webhook to be within cooldown")
+	}
+	if state.within("https://b.example/hook", now.Add(time.Minute), time.Hour) {
+		t.Error("expected another webhook not to be within cooldown")
+	}
+}
+
+func TestCooldownCorruptStateFile(t *testing.T) {
+	t.Parallel()
+
+	stateFile := filepath.Join(t.TempDir(), "teams-state.json")
+	if err := os.WriteFile(stateFile, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":      "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"cooldown_seconds": 60,
+			"state_file":       stateFile,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success || len(bodies) != 1 {
+		t.Fatalf("expected a corrupt state file not to block the notification: %v %+v", err, resp)
+	}
+	if _, err := loadCooldownState(stateFile); err != nil {
+		t.Errorf("expected the state file to be rewritten, got %v", err)
+	}
+}
+
+func TestValidateCooldown(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantValid bool
+	}{
+		{name: "valid", config: map[string]any{"cooldown_seconds": 300, "state_file": "/var/lib/relicta/teams-state.json"}, wantValid: true},
+		{name: "negative", config: map[string]any{"cooldown_seconds": -1}},
+		{name: "relative_state_file", config: map[string]any{"state_file": "teams-state.json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Errorf("expected Valid=%v, got %+v", tt.wantValid, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// deprecationTypes are conventional commit types that announce a deprecation.
+var deprecationTypes = map[string]bool{
+	"deprecate":   true,
+	"deprecated":  true,
+	"deprecation": true,
+}
+
+// collectDeprecations returns the deprecation commits across all categories,
+// detected by commit type or a "deprecate" prefix in the description.
+func collectDeprecations(changes *plugin.CategorizedChanges) []plugin.ConventionalCommit {
+	if changes == nil {
+		return nil
+	}
+
+	var deprecations []plugin.ConventionalCommit
+	seen := make(map[string]bool)
+	for _, category := range [][]plugin.ConventionalCommit{
+		changes.Features, changes.Fixes, changes.Breaking, changes.Performance,
+		changes.Refactor, changes.Docs, changes.Other,
+	} {
+		for _, commit := range category {
+			if !isDeprecation(commit) {
+				continue
+			}
+			if commit.Hash != "" {
+				if seen[commit.Hash] {
+					continue
+				}
+				seen[commit.Hash] = true
+			}
+			deprecations = append(deprecations, commit)
+		}
+	}
+	return deprecations
+}
+
+// isDeprecation reports whether a commit announces a deprecation.
+func isDeprecation(commit plugin.ConventionalCommit) bool {
+	if deprecationTypes[strings.ToLower(commit.Type)] {
+		return true
+	}
+	return strings.HasPrefix(strings.ToLower(commit.Description), "deprecate")
+}
+
+// buildDeprecationsSection renders deprecations as an amber section.
+func buildDeprecationsSection(deprecations []plugin.ConventionalCommit, issueRepoURL string) AdaptiveElement {
+	lines := make([]string, 0, len(deprecations))
+	for _, commit := range deprecations {
+		line := linkifyIssues(html.EscapeString(commit.Description), issueRepoURL)
+		if commit.Scope != "" {
+			line = fmt.Sprintf("**%s:** %s", html.EscapeString(commit.Scope), line)
+		}
+		lines = append(lines, "- "+line)
+	}
+
+	return AdaptiveElement{
+		Type:      "Container",
+		Style:     "warning",
+		Separator: true,
+		Spacing:   "medium",
+		Items: []AdaptiveElement{
+			{Type: "TextBlock", Text: "⚠️ Deprecations", Weight: "bolder", Color: "warning"},
+			{Type: "TextBlock", Text: strings.Join(lines, "\n"), Wrap: true},
+		},
+	}
+}
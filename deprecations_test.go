@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCollectDeprecations(t *testing.T) {
+	t.Parallel()
+
+	changes := &plugin.CategorizedChanges{
+		Features: []plugin.ConventionalCommit{
+			{Hash: "a1", Type: "feat", Description: "add v2 API"},
+			{Hash: "a2", Type: "feat", Description: "deprecate v1 API"},
+		},
+		Other: []plugin.ConventionalCommit{
+			{Hash: "b1", Type: "deprecate", Scope: "cli", Description: "old --foo flag"},
+			{Hash: "a2", Type: "feat", Description: "deprecate v1 API"},
+		},
+	}
+
+	got := collectDeprecations(changes)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 deprecations, got %d: %+v", len(got), got)
+	}
+	if got[0].Hash != "a2" || got[1].Hash != "b1" {
+		t.Errorf("unexpected deprecations: %+v", got)
+	}
+
+	if collectDeprecations(nil) != nil {
+		t.Error("expected no deprecations without changes")
+	}
+}
+
+func TestDeprecationsSection(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version: "2.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Other: []plugin.ConventionalCommit{
+				{Hash: "b1", Type: "deprecate", Scope: "cli", Description: "old <foo> flag"},
+			},
+		},
+	}
+	p := &TeamsPlugin{}
+
+	body := p.buildSuccessNotification(&Config{ShowDeprecations: true}, releaseCtx).body
+	section := body[len(body)-1]
+	if section.Type != "Container" || section.Style != "warning" {
+		t.Fatalf("expected amber container, got %+v", section)
+	}
+	if section.Items[0].Text != "⚠️ Deprecations" || section.Items[0].Color != "warning" {
+		t.Errorf("unexpected heading: %+v", section.Items[0])
+	}
+	if !strings.Contains(section.Items[1].Text, "**cli:** old &lt;foo&gt; flag") {
+		t.Errorf("unexpected deprecation list: %q", section.Items[1].Text)
+	}
+
+	body = p.buildSuccessNotification(&Config{}, releaseCtx).body
+	if last := body[len(body)-1]; last.Style == "warning" {
+		t.Error("expected no deprecations section when disabled")
+	}
+}
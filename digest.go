@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// buildDigestText builds the one-line summary posted to the digest webhook.
+func buildDigestText(status string, releaseCtx plugin.ReleaseContext, releaseURL string) string {
+	if status == StatusApproval {
+		return fmt.Sprintf("👍 Release %s approved", releaseCtx.Version)
+	}
+	if status == StatusError {
+		text := fmt.Sprintf("❌ Release %s failed", releaseCtx.Version)
+		if releaseCtx.Branch != "" {
+			text += fmt.Sprintf(" on %s", releaseCtx.Branch)
+		}
+		return text
+	}
+
+	text := fmt.Sprintf("✅ Release %s published", releaseCtx.Version)
+	if releaseType := normalizeReleaseType(releaseCtx.ReleaseType); releaseType != "" {
+		text += fmt.Sprintf(" (%s)", releaseType)
+	}
+	if releaseURL != "" {
+		text += fmt.Sprintf(" · [View release](%s)", releaseURL)
+	}
+	return text
+}
+
+// sendDigest posts a compact summary to the digest webhook, if configured.
+// Digest failures never fail the main notification; they are reported as a
+// "digest_warning" output on resp instead.
+func (p *TeamsPlugin) sendDigest(ctx context.Context, cfg *Config, resp *plugin.ExecuteResponse, text string) {
+	if cfg.DigestWebhookURL == "" {
+		return
+	}
+
+	msg := p.buildTeamsMessage([]AdaptiveElement{
+		{Type: "TextBlock", Text: text, Wrap: true},
+	}, nil, nil, "")
+
+	if err := p.deliver(ctx, cfg, cfg.DigestWebhookURL, msg); err != nil {
+		p.getLogger().Warn("digest notification failed", "webhook", redactWebhookURL(cfg.DigestWebhookURL), "error", redactError(err, cfg.DigestWebhookURL))
+		if resp.Outputs == nil {
+			resp.Outputs = map[string]any{}
+		}
+		resp.Outputs["digest_warning"] = fmt.Sprintf("failed to send digest: %s", redactError(err, cfg.DigestWebhookURL))
+	}
+}
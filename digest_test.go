@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestDigestWebhook(t *testing.T) {
+	t.Parallel()
+
+	const (
+		mainWebhook   = "https://example.webhook.office.com/webhookb2/main/IncomingWebhook/1/2"
+		digestWebhook = "https://example.webhook.office.com/webhookb2/digest/IncomingWebhook/3/4"
+	)
+
+	tests := []struct {
+		name          string
+		hook          plugin.Hook
+		digestStatus  int
+		wantDigest    string
+		wantWarning   bool
+		wantSentCount int
+	}{
+		{
+			name:          "success_sends_both",
+			hook:          plugin.HookPostPublish,
+			digestStatus:  http.StatusOK,
+			wantDigest:    "✅ Release 1.2.3 published (minor)",
+			wantSentCount: 2,
+		},
+		{
+			name:          "error_sends_both",
+			hook:          plugin.HookOnError,
+			digestStatus:  http.StatusOK,
+			wantDigest:    "❌ Release 1.2.3 failed on main",
+			wantSentCount: 2,
+		},
+		{
+			name:          "digest_failure_is_non_fatal",
+			hook:          plugin.HookPostPublish,
+			digestStatus:  http.StatusInternalServerError,
+			wantWarning:   true,
+			wantSentCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := map[string][]byte{}
+			p := &TeamsPlugin{
+				httpClient: &MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						body, _ := io.ReadAll(req.Body)
+						sent[req.URL.String()] = body
+						status := http.StatusOK
+						if req.URL.String() == digestWebhook {
+							status = tt.digestStatus
+						}
+						return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+					},
+				},
+			}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: tt.hook,
+				Config: map[string]any{
+					"webhook_url":        mainWebhook,
+					"digest_webhook_url": digestWebhook,
+					"max_retries":        0,
+				},
+				Context: plugin.ReleaseContext{
+					Version:       "1.2.3",
+					TagName:       "v1.2.3",
+					ReleaseType:   "minor",
+					Branch:        "main",
+					RepositoryURL: "https://github.com/test/repo",
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !resp.Success {
+				t.Errorf("expected main notification to succeed, got %s", resp.Error)
+			}
+			if len(sent) != tt.wantSentCount {
+				t.Fatalf("expected %d sends, got %d", tt.wantSentCount, len(sent))
+			}
+
+			if tt.wantDigest != "" {
+				card := decodeCard(t, sent[digestWebhook])
+				if len(card.Body) != 1 || !strings.HasPrefix(card.Body[0].Text, tt.wantDigest) {
+					t.Errorf("expected one-line digest %q, got %+v", tt.wantDigest, card.Body)
+				}
+			}
+
+			_, hasWarning := resp.Outputs["digest_warning"]
+			if hasWarning != tt.wantWarning {
+				t.Errorf("expected digest_warning=%v, got outputs %v", tt.wantWarning, resp.Outputs)
+			}
+			if hasWarning && strings.Contains(resp.Outputs["digest_warning"].(string), "digest/IncomingWebhook") {
+				t.Error("expected digest webhook path to be redacted from warning")
+			}
+		})
+	}
+}
+
+func TestValidateDigestWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url":        "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"digest_webhook_url": "https://evil.example.com/hook",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || resp.Errors[0].Field != "digest_webhook_url" {
+		t.Errorf("expected digest_webhook_url format error, got %+v", resp.Errors)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxExtraFacts is the default cap on extra_facts rendered per card.
+const DefaultMaxExtraFacts = 15
+
+// parseExtraFacts reads the extra_facts map of label to value, skipping
+// blank labels and non-string values.
+func parseExtraFacts(raw map[string]any) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	facts := make(map[string]string, len(raw))
+	for label, value := range raw {
+		s, ok := value.(string)
+		if label = strings.TrimSpace(label); label == "" || !ok {
+			continue
+		}
+		facts[label] = s
+	}
+	return facts
+}
+
+// buildExtraFacts returns the extra facts sorted by label and capped at limit,
+// along with the number of facts left out. A limit of zero disables the cap.
+func buildExtraFacts(extra map[string]string, limit int) ([]infoFact, int) {
+	labels := make([]string, 0, len(extra))
+	for label := range extra {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	overflow := 0
+	if limit > 0 && len(labels) > limit {
+		overflow = len(labels) - limit
+		labels = labels[:limit]
+	}
+
+	facts := make([]infoFact, 0, len(labels))
+	for _, label := range labels {
+		facts = append(facts, infoFact{Label: label, Value: extra[label]})
+	}
+	return facts, overflow
+}
+
+// buildExtraFactsOverflow renders the "...and N more" line for capped facts.
+func buildExtraFactsOverflow(overflow int) AdaptiveElement {
+	return AdaptiveElement{
+		Type:     "TextBlock",
+		Text:     fmt.Sprintf("...and %d more", overflow),
+		IsSubtle: true,
+		Spacing:  "small",
+	}
+}
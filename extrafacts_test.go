@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExtraFactsOverflow(t *testing.T) {
+	t.Parallel()
+
+	extra := map[string]any{}
+	for i := 20; i >= 1; i-- {
+		extra[fmt.Sprintf("Fact %02d", i)] = fmt.Sprintf("value %d", i)
+	}
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":     "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"extra_facts":     extra,
+			"max_extra_facts": 15,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	card := decodeCard(t, bodies[0])
+	facts := infoFacts(card.Body)
+	for i := 1; i <= 20; i++ {
+		label := fmt.Sprintf("Fact %02d:", i)
+		_, ok := facts[label]
+		if want := i <= 15; ok != want {
+			t.Errorf("%s: expected present=%v", label, want)
+		}
+	}
+
+	found := false
+	for _, elem := range card.Body {
+		if elem.Text == "...and 5 more" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected overflow line")
+	}
+}
+
+func TestBuildExtraFactsSorted(t *testing.T) {
+	t.Parallel()
+
+	facts, overflow := buildExtraFacts(map[string]string{"b": "2", "c": "3", "a": "1"}, 0)
+	if overflow != 0 || len(facts) != 3 {
+		t.Fatalf("expected 3 facts without overflow, got %d (+%d)", len(facts), overflow)
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if facts[i].Label != want {
+			t.Errorf("fact %d: expected %q, got %q", i, want, facts[i].Label)
+		}
+	}
+}
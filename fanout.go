@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultFanoutConcurrency is the default number of webhooks sent to at once.
+const DefaultFanoutConcurrency = 4
+
+// Policies accepted by fanout_success_policy.
+const (
+	FanoutSuccessAll = "all"
+	FanoutSuccessAny = "any"
+)
+
+// Target statuses reported in the "targets" output.
+const (
+	TargetStatusSent   = "sent"
+	TargetStatusFailed = "failed"
+)
+
+// webhookTargets returns the webhooks a notification with the given status is
+// sent to: webhook_urls when set, with the routed webhook (webhook_url or its
+// per-status override) appended unless already listed. Duplicates are dropped.
+func (cfg *Config) webhookTargets(status string) []string {
+	var targets []string
+	for _, webhookURL := range cfg.WebhookURLs {
+		if webhookURL != "" && !slices.Contains(targets, webhookURL) {
+			targets = append(targets, webhookURL)
+		}
+	}
+	routed := cfg.webhookFor(status)
+	if len(targets) == 0 || (routed != "" && !slices.Contains(targets, routed)) {
+		targets = append(targets, routed)
+	}
+	return targets
+}
+
+// fanOut calls send for every target and returns the errors in target order.
+// With concurrent_fanout, at most fanout_concurrency sends run at once;
+// otherwise targets are sent to one after another.
+func (p *TeamsPlugin) fanOut(cfg *Config, targets []string, send func(i int, webhookURL string) error) []error {
+	errs := make([]error, len(targets))
+	workers := cfg.FanoutConcurrency
+	if !cfg.ConcurrentFanout || len(targets) == 1 || workers < 2 {
+		for i, webhookURL := range targets {
+			errs[i] = send(i, webhookURL)
+		}
+		return errs
+	}
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, webhookURL := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = send(i, webhookURL)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// fanOutFailures describes each failed target with its redacted webhook.
+func fanOutFailures(targets []string, errs []error) []string {
+	var failures []string
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, redactWebhookURL(targets[i])+": "+redactError(err, targets[i]))
+		}
+	}
+	return failures
+}
+
+// fanOutSucceeded applies fanout_success_policy to the results of a fan-out.
+func fanOutSucceeded(cfg *Config, targets, failures int) bool {
+	if cfg.FanoutSuccessPolicy == FanoutSuccessAny {
+		return failures < targets
+	}
+	return failures == 0
+}
+
+// fanOutResults describes the outcome for each target, for the "targets"
+// output. Webhooks are redacted and errors have them masked.
+func fanOutResults(targets []string, errs []error, attempts []int) []map[string]any {
+	results := make([]map[string]any, len(targets))
+	for i, webhookURL := range targets {
+		result := map[string]any{
+			"webhook":  redactWebhookURL(webhookURL),
+			"status":   TargetStatusSent,
+			"attempts": attempts[i],
+		}
+		if errs[i] != nil {
+			result["status"] = TargetStatusFailed
+			result["error"] = redactError(errs[i], webhookURL)
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// attemptCounterKey is the context key of the counter set by withAttemptCounter.
+type attemptCounterKey struct{}
+
+// withAttemptCounter returns a context whose HTTP attempts are counted, so
+// the attempts made for one target can be reported.
+func withAttemptCounter(ctx context.Context) (context.Context, *atomic.Int32) {
+	counter := &atomic.Int32{}
+	return context.WithValue(ctx, attemptCounterKey{}, counter), counter
+}
+
+// countAttempt records an HTTP attempt on the context's counter, if any.
+func countAttempt(ctx context.Context) {
+	if counter, ok := ctx.Value(attemptCounterKey{}).(*atomic.Int32); ok {
+		counter.Add(1)
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// fanoutClient records the URLs hit and the peak number of concurrent
+// requests. Requests to hosts in failHosts return 500.
+type fanoutClient struct {
+	mu        sync.Mutex
+	hits      []string
+	inFlight  int
+	peak      int
+	failHosts map[string]bool
+}
+
+func (c *fanoutClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.hits = append(c.hits, req.URL.String())
+	c.inFlight++
+	c.peak = max(c.peak, c.inFlight)
+	c.mu.Unlock()
+
+	// Give other workers a chance to overlap
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	status := http.StatusOK
+	if c.failHosts[req.URL.Host] {
+		status = http.StatusInternalServerError
+	}
+	return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+}
+
+func fanoutWebhooks(n int) []any {
+	urls := make([]any, n)
+	for i := range urls {
+		urls[i] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/" + string(rune('a'+i))
+	}
+	return urls
+}
+
+func TestWebhookTargets(t *testing.T) {
+	t.Parallel()
+
+	const (
+		a = "https://a.webhook.office.com/1"
+		b = "https://b.webhook.office.com/2"
+		c = "https://c.webhook.office.com/3"
+	)
+
+	tests := []struct {
+		name   string
+		cfg    *Config
+		status string
+		want   []string
+	}{
+		{name: "only_singular", cfg: &Config{WebhookURL: a}, status: StatusSuccess, want: []string{a}},
+		{name: "only_plural", cfg: &Config{WebhookURLs: []string{c, b}}, status: StatusSuccess, want: []string{c, b}},
+		{name: "both_set", cfg: &Config{WebhookURL: a, WebhookURLs: []string{c, b}}, status: StatusSuccess, want: []string{c, b, a}},
+		{name: "both_set_duplicate", cfg: &Config{WebhookURL: a, WebhookURLs: []string{c, a, c}}, status: StatusError, want: []string{c, a}},
+		{
+			name:   "routed_override_appended",
+			cfg:    &Config{WebhookURL: a, WebhookURLSuccess: b, WebhookURLs: []string{c}},
+			status: StatusSuccess,
+			want:   []string{c, b},
+		},
+		{name: "none", cfg: &Config{}, status: StatusSuccess, want: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.webhookTargets(tt.status); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookURLPrecedence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   map[string]any
+		wantNote bool
+	}{
+		{name: "only_singular", config: map[string]any{"webhook_url": fanoutWebhooks(1)[0]}},
+		{name: "only_plural", config: map[string]any{"webhook_urls": fanoutWebhooks(2)}},
+		{name: "both_set", config: map[string]any{"webhook_url": fanoutWebhooks(3)[2], "webhook_urls": fanoutWebhooks(2)}, wantNote: true},
+	}
+
+	p := &TeamsPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Valid {
+				t.Fatalf("expected valid config, got %+v", resp.Errors)
+			}
+			noted := false
+			for _, e := range resp.Errors {
+				noted = noted || (e.Field == "webhook_urls" && e.Code == "precedence" && isWarning(e))
+			}
+			if noted != tt.wantNote {
+				t.Errorf("expected a precedence note=%v, got %+v", tt.wantNote, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestConcurrentFanout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   map[string]any
+		wantPeak func(peak int) bool
+	}{
+		{
+			name:     "bounded_pool",
+			config:   map[string]any{"fanout_concurrency": 2},
+			wantPeak: func(peak int) bool { return peak == 2 },
+		},
+		{
+			name:     "sequential",
+			config:   map[string]any{"concurrent_fanout": false},
+			wantPeak: func(peak int) bool { return peak == 1 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fanoutClient{}
+			p := &TeamsPlugin{httpClient: client}
+			tt.config["webhook_urls"] = fanoutWebhooks(6)
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  tt.config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+			if resp.Message != "Sent Teams success notification to 6 webhooks" {
+				t.Errorf("unexpected message %q", resp.Message)
+			}
+
+			seen := map[string]bool{}
+			for _, hit := range client.hits {
+				seen[hit] = true
+			}
+			for _, webhookURL := range tt.config["webhook_urls"].([]any) {
+				if !seen[webhookURL.(string)] {
+					t.Errorf("expected %s to be sent to", webhookURL)
+				}
+			}
+			if len(client.hits) != 6 {
+				t.Errorf("expected 6 requests, got %d", len(client.hits))
+			}
+			if !tt.wantPeak(client.peak) {
+				t.Errorf("unexpected peak concurrency %d", client.peak)
+			}
+		})
+	}
+}
+
+func TestConcurrentFanoutAggregatesFailures(t *testing.T) {
+	t.Parallel()
+
+	client := &fanoutClient{failHosts: map[string]bool{"bad.webhook.office.com": true}}
+	p := &TeamsPlugin{httpClient: client}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url": "https://good.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"webhook_urls": []any{
+				"https://bad.webhook.office.com/webhookb2/123/IncomingWebhook/456/secret",
+				"https://other.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			},
+			"max_retries": 0,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected a partial failure to fail the notification")
+	}
+	if len(client.hits) != 3 {
+		t.Errorf("expected every webhook to be sent to, got %d requests", len(client.hits))
+	}
+	if !strings.HasPrefix(resp.Error, "failed to send Teams success notification to 1 of 3 webhooks: https://bad.webhook.office.com/[redacted]: ") {
+		t.Errorf("unexpected error %q", resp.Error)
+	}
+	if strings.Contains(resp.Error, "secret") {
+		t.Errorf("expected the webhook path to be redacted, got %q", resp.Error)
+	}
+}
+
+func TestFanoutSuccessPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      string
+		failHosts   []string
+		wantSuccess bool
+		wantMessage string
+	}{
+		{name: "all_partial_failure", failHosts: []string{"bad.webhook.office.com"}},
+		{
+			name:        "any_partial_failure",
+			policy:      "any",
+			failHosts:   []string{"bad.webhook.office.com"},
+			wantSuccess: true,
+			wantMessage: "Sent Teams success notification to 1 of 2 webhooks",
+		},
+		{
+			name:      "any_total_failure",
+			policy:    "any",
+			failHosts: []string{"bad.webhook.office.com", "good.webhook.office.com"},
+		},
+		{name: "all_succeed", wantSuccess: true, wantMessage: "Sent Teams success notification to 2 webhooks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fanoutClient{failHosts: map[string]bool{}}
+			for _, host := range tt.failHosts {
+				client.failHosts[host] = true
+			}
+			p := &TeamsPlugin{
+				httpClient: client,
+				sleepFunc:  func(context.Context, time.Duration) error { return nil },
+			}
+			config := map[string]any{
+				"webhook_url":  "https://good.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				"webhook_urls": []any{"https://bad.webhook.office.com/webhookb2/123/IncomingWebhook/456/secret"},
+				"max_retries":  1,
+			}
+			if tt.policy != "" {
+				config["fanout_success_policy"] = tt.policy
+			}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("expected Success=%v, got %+v", tt.wantSuccess, resp)
+			}
+			if tt.wantMessage != "" && resp.Message != tt.wantMessage {
+				t.Errorf("unexpected message %q", resp.Message)
+			}
+
+			results, ok := resp.Outputs["targets"].([]map[string]any)
+			if !ok || len(results) != 2 {
+				t.Fatalf("expected two target results, got %v", resp.Outputs["targets"])
+			}
+			for _, result := range results {
+				webhook := result["webhook"].(string)
+				failed := slices.ContainsFunc(tt.failHosts, func(host string) bool {
+					return strings.Contains(webhook, "://"+host+"/")
+				})
+				wantStatus, wantAttempts := "sent", 1
+				if failed {
+					wantStatus, wantAttempts = "failed", 2
+				}
+				if result["status"] != wantStatus || result["attempts"] != wantAttempts {
+					t.Errorf("unexpected result %v", result)
+				}
+				if _, hasError := result["error"]; hasError != failed {
+					t.Errorf("expected an error only for failed targets, got %v", result)
+				}
+				if strings.Contains(webhook, "secret") || strings.Contains(fmt.Sprint(result["error"]), "secret") {
+					t.Errorf("expected the webhook to be redacted, got %v", result)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFanout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantValid bool
+	}{
+		{name: "webhook_urls_only", config: map[string]any{"webhook_urls": fanoutWebhooks(2)}, wantValid: true},
+		{name: "invalid_entry", config: map[string]any{"webhook_urls": []any{"http://example.com/hook"}}},
+		{
+			name: "unknown_success_policy",
+			config: map[string]any{
+				"webhook_urls":          fanoutWebhooks(2),
+				"fanout_success_policy": "most",
+			},
+		},
+		{
+			name: "zero_concurrency",
+			config: map[string]any{
+				"webhook_urls":       fanoutWebhooks(2),
+				"fanout_concurrency": 0,
+			},
+		},
+	}
+
+	p := &TeamsPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Errorf("expected Valid=%v, got %+v", tt.wantValid, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// adaptiveCardTimeLayout is the RFC 3339 form accepted by the Adaptive Card
+// DATE and TIME functions, which reject fractional seconds.
+const adaptiveCardTimeLayout = "2006-01-02T15:04:05Z07:00"
+
+// buildTimestampFooter renders a subtle "Sent" footer using the Adaptive Card
+// DATE and TIME functions, so Teams formats the time in each viewer's locale
+// and timezone. loc sets the offset of the emitted timestamp, which clients
+// without function support display as-is.
+func buildTimestampFooter(now time.Time, loc *time.Location) AdaptiveElement {
+	if loc != nil {
+		now = now.In(loc)
+	}
+	stamp := now.Format(adaptiveCardTimeLayout)
+	return AdaptiveElement{
+		Type:      "TextBlock",
+		Text:      fmt.Sprintf("Sent {{DATE(%s, SHORT)}} {{TIME(%s)}}", stamp, stamp),
+		Size:      "small",
+		IsSubtle:  true,
+		Separator: true,
+		Spacing:   "medium",
+	}
+}
+
+// buildExpiryNote renders a subtle note saying when the card's information
+// expires, formatted by Teams like the timestamp footer.
+func buildExpiryNote(expiresAt time.Time, loc *time.Location) AdaptiveElement {
+	if loc != nil {
+		expiresAt = expiresAt.In(loc)
+	}
+	stamp := expiresAt.Format(adaptiveCardTimeLayout)
+	return AdaptiveElement{
+		Type:     "TextBlock",
+		Text:     fmt.Sprintf("Expires {{DATE(%s, SHORT)}} {{TIME(%s)}}", stamp, stamp),
+		Size:     "small",
+		IsSubtle: true,
+		Spacing:  "small",
+	}
+}
+
+// loadTimezone returns the location for an IANA timezone name, or UTC when
+// name is empty or unknown.
+func loadTimezone(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
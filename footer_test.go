@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestBuildTimestampFooter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 10, 12, 30, 45, 123456789, time.UTC)
+	tests := []struct {
+		name     string
+		timezone string
+		want     string
+	}{
+		{name: "utc", want: "Sent {{DATE(2024-05-10T12:30:45Z, SHORT)}} {{TIME(2024-05-10T12:30:45Z)}}"},
+		{name: "timezone", timezone: "Europe/Berlin", want: "Sent {{DATE(2024-05-10T14:30:45+02:00, SHORT)}} {{TIME(2024-05-10T14:30:45+02:00)}}"},
+		{name: "unknown timezone", timezone: "Mars/Olympus", want: "Sent {{DATE(2024-05-10T12:30:45Z, SHORT)}} {{TIME(2024-05-10T12:30:45Z)}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			footer := buildTimestampFooter(now, loadTimezone(tt.timezone))
+			if footer.Text != tt.want {
+				t.Errorf("got %q, want %q", footer.Text, tt.want)
+			}
+			if !footer.IsSubtle {
+				t.Error("expected subtle footer")
+			}
+		})
+	}
+}
+
+func TestFooterTimestampRendered(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{
+		httpClient: recordingClient(&bodies),
+		now:        func() time.Time { return time.Date(2024, 5, 1, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60)) },
+	}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":      "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"footer_timestamp": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	card := decodeCard(t, bodies[0])
+	footer := card.Body[len(card.Body)-1].Text
+	pattern := regexp.MustCompile(`^Sent \{\{DATE\((\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z), SHORT\)\}\} \{\{TIME\((\S+)\)\}\}$`)
+	m := pattern.FindStringSubmatch(footer)
+	if m == nil {
+		t.Fatalf("unexpected footer %q", footer)
+	}
+	if m[1] != m[2] {
+		t.Errorf("expected DATE and TIME to use the same timestamp, got %q and %q", m[1], m[2])
+	}
+	if m[1] != "2024-05-01T12:30:00Z" {
+		t.Errorf("expected the clock's time in UTC, got %q", m[1])
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for tz, wantValid := range map[string]bool{"Europe/Berlin": true, "UTC": true, "Mars/Olympus": false} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"timezone":    tz,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("timezone %q: expected Valid=%v, got %+v", tz, wantValid, resp.Errors)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Run "go test -run TestGolden -update" to rewrite the golden files after an
+// intentional card layout change, and review the diff.
+var updateGolden = flag.Bool("update", false, "update golden card payloads")
+
+// volatileGoldenKeys are payload keys whose values change between runs.
+var volatileGoldenKeys = map[string]bool{
+	"timestamp": true,
+}
+
+// normalizeGolden re-encodes a payload with volatile values replaced.
+func normalizeGolden(t *testing.T, payload []byte) []byte {
+	t.Helper()
+
+	var v any
+	if err := json.Unmarshal(payload, &v); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	v = scrubVolatile(v)
+
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	return append(out, '\n')
+}
+
+func scrubVolatile(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if volatileGoldenKeys[key] {
+				v[key] = "<volatile>"
+				continue
+			}
+			v[key] = scrubVolatile(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = scrubVolatile(value)
+		}
+	}
+	return v
+}
+
+// assertGolden compares a card payload with testdata/golden/<name>.json.
+func assertGolden(t *testing.T, name string, msg TeamsMessage) {
+	t.Helper()
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("failed to marshal message: %v", err)
+	}
+	got := normalizeGolden(t, payload)
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("card payload differs from %s (run with -update if intentional)\ngot:\n%s", path, got)
+	}
+}
+
+// goldenReleaseContext is a representative release for golden cards.
+func goldenReleaseContext() plugin.ReleaseContext {
+	return plugin.ReleaseContext{
+		Version:         "1.2.0",
+		PreviousVersion: "1.1.0",
+		TagName:         "v1.2.0",
+		ReleaseType:     "minor",
+		RepositoryURL:   "https://github.com/relicta-tech/example",
+		Branch:          "main",
+		ReleaseNotes:    "## Features\n- Add dark mode\n\n## Fixes\n- Fix login redirect",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Hash: "a1", Type: "feat", Description: "add dark mode"}},
+			Fixes:    []plugin.ConventionalCommit{{Hash: "b1", Type: "fix", Description: "fix login redirect"}},
+		},
+	}
+}
+
+func TestGoldenCards(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+	})
+	releaseCtx := goldenReleaseContext()
+
+	tests := []struct {
+		name string
+		n    notification
+	}{
+		{name: "success_default", n: p.buildSuccessNotification(cfg, releaseCtx)},
+		{name: "error_default", n: p.buildErrorNotification(cfg, releaseCtx)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertGolden(t, tt.name, p.buildNotificationMessage(cfg, tt.n, nil))
+		})
+	}
+}
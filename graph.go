@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Environment variables holding the Microsoft Graph app credentials used by resolve_mentions.
+const (
+	EnvGraphTenantID     = "TEAMS_GRAPH_TENANT_ID"
+	EnvGraphClientID     = "TEAMS_GRAPH_CLIENT_ID"
+	EnvGraphClientSecret = "TEAMS_GRAPH_CLIENT_SECRET"
+)
+
+const (
+	graphLoginURL = "https://login.microsoftonline.com"
+	graphAPIURL   = "https://graph.microsoft.com/v1.0"
+	graphScope    = "https://graph.microsoft.com/.default"
+
+	// maxGraphResponseBytes bounds how much of a Graph response is read.
+	maxGraphResponseBytes = 1 << 20
+	// graphTokenExpirySkew renews tokens slightly before they expire.
+	graphTokenExpirySkew = time.Minute
+)
+
+// MentionResolver resolves a mention user (UPN, email or display name) to the
+// Azure AD user Teams needs for a reliable mention.
+type MentionResolver interface {
+	ResolveMention(ctx context.Context, user string) (TeamsMentionedUser, error)
+}
+
+// graphMentionResolver looks users up in Microsoft Graph using the client
+// credentials flow. Tokens and resolved users are cached.
+type graphMentionResolver struct {
+	client       HTTPClient
+	tenantID     string
+	clientID     string
+	clientSecret string
+	now          func() time.Time
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	users       map[string]TeamsMentionedUser
+}
+
+func newGraphMentionResolver(client HTTPClient, tenantID, clientID, clientSecret string) *graphMentionResolver {
+	return &graphMentionResolver{
+		client:       client,
+		tenantID:     tenantID,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		now:          time.Now,
+		users:        make(map[string]TeamsMentionedUser),
+	}
+}
+
+// graphResolverKey identifies a cached resolver: the app registration and
+// the transport its lookups go through.
+type graphResolverKey struct {
+	tenantID string
+	clientID string
+	client   HTTPClient
+}
+
+// graphResolvers caches resolvers per app registration and transport, so
+// lookups are reused across hook invocations in the same process.
+var graphResolvers = struct {
+	mu        sync.Mutex
+	resolvers map[graphResolverKey]*graphMentionResolver
+}{resolvers: make(map[graphResolverKey]*graphMentionResolver)}
+
+// getMentionResolver returns the mention resolver to use. Graph lookups go
+// through the same configured transport as webhook sends (proxy, CA file,
+// pinning and timeouts), with redirects limited to the Graph hosts.
+func (p *TeamsPlugin) getMentionResolver(cfg *Config) (MentionResolver, error) {
+	if p.mentionResolver != nil {
+		return p.mentionResolver, nil
+	}
+
+	client, err := p.httpClientFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	key := graphResolverKey{tenantID: cfg.GraphTenantID, clientID: cfg.GraphClientID, client: client}
+	graphResolvers.mu.Lock()
+	defer graphResolvers.mu.Unlock()
+	r, ok := graphResolvers.resolvers[key]
+	if !ok || r.clientSecret != cfg.GraphClientSecret {
+		r = newGraphMentionResolver(graphHTTPClient(client), cfg.GraphTenantID, cfg.GraphClientID, cfg.GraphClientSecret)
+		graphResolvers.resolvers[key] = r
+	}
+	return r, nil
+}
+
+// graphHTTPClient returns client with its webhook redirect policy replaced by
+// checkGraphRedirect. Injected clients are returned as-is.
+func graphHTTPClient(client HTTPClient) HTTPClient {
+	hc, ok := client.(*http.Client)
+	if !ok {
+		return client
+	}
+	graph := *hc
+	graph.CheckRedirect = checkGraphRedirect
+	return &graph
+}
+
+// checkGraphRedirect restricts redirects to a short chain of HTTPS Graph and
+// login hosts.
+func checkGraphRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 3 {
+		return fmt.Errorf("too many redirects")
+	}
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to non-HTTPS URL not allowed")
+	}
+	switch req.URL.Hostname() {
+	case "graph.microsoft.com", "login.microsoftonline.com":
+		return nil
+	}
+	return fmt.Errorf("redirect away from Microsoft Graph not allowed")
+}
+
+// resolveMentions looks up every mention user when resolve_mentions is set.
+// Users that cannot be resolved are left out and keep their email-based mention.
+func (p *TeamsPlugin) resolveMentions(ctx context.Context, cfg *Config) map[string]TeamsMentionedUser {
+	if !cfg.ResolveMentions || len(cfg.MentionUsers) == 0 {
+		return nil
+	}
+
+	resolver, err := p.getMentionResolver(cfg)
+	if err != nil {
+		p.getLogger().Warn("mention lookups unavailable; falling back to email mentions", "error", err.Error())
+		return nil
+	}
+	resolved := make(map[string]TeamsMentionedUser, len(cfg.MentionUsers))
+	for _, user := range cfg.MentionUsers {
+		mentioned, err := resolver.ResolveMention(ctx, user)
+		if err != nil {
+			p.getLogger().Warn("mention lookup failed; falling back to email mention", "user", user, "error", err.Error())
+			continue
+		}
+		resolved[user] = mentioned
+	}
+	return resolved
+}
+
+// applyResolvedMentions replaces email-based mention entities with resolved users.
+func applyResolvedMentions(msg *TeamsMessage, resolved map[string]TeamsMentionedUser) {
+	if len(resolved) == 0 || len(msg.Attachments) == 0 || msg.Attachments[0].Content.MSTeams == nil {
+		return
+	}
+	for i, entity := range msg.Attachments[0].Content.MSTeams.Entities {
+		if entity.Mentioned == nil {
+			continue
+		}
+		if user, ok := resolved[entity.Mentioned.ID]; ok {
+			msg.Attachments[0].Content.MSTeams.Entities[i].Mentioned = &user
+		}
+	}
+}
+
+// ResolveMention returns the Azure AD object ID and display name for user.
+// Values containing "@" are looked up as a UPN; others by exact display name.
+func (r *graphMentionResolver) ResolveMention(ctx context.Context, user string) (TeamsMentionedUser, error) {
+	r.mu.Lock()
+	cached, ok := r.users[user]
+	r.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	token, err := r.accessToken(ctx)
+	if err != nil {
+		return TeamsMentionedUser{}, err
+	}
+
+	var mentioned TeamsMentionedUser
+	if strings.Contains(user, "@") {
+		mentioned, err = r.lookupUser(ctx, token, user)
+	} else {
+		mentioned, err = r.lookupDisplayName(ctx, token, user)
+	}
+	if err != nil {
+		return TeamsMentionedUser{}, err
+	}
+
+	r.mu.Lock()
+	r.users[user] = mentioned
+	r.mu.Unlock()
+	return mentioned, nil
+}
+
+// graphUser is the subset of a Graph user resource used for mentions.
+type graphUser struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+func (u graphUser) mentioned() TeamsMentionedUser {
+	return TeamsMentionedUser{ID: u.ID, Name: u.DisplayName}
+}
+
+func (r *graphMentionResolver) lookupUser(ctx context.Context, token, upn string) (TeamsMentionedUser, error) {
+	endpoint := graphAPIURL + "/users/" + url.PathEscape(upn) + "?$select=id,displayName"
+	var user graphUser
+	if err := r.get(ctx, token, endpoint, &user); err != nil {
+		return TeamsMentionedUser{}, err
+	}
+	if user.ID == "" {
+		return TeamsMentionedUser{}, fmt.Errorf("graph returned no ID for %s", upn)
+	}
+	return user.mentioned(), nil
+}
+
+func (r *graphMentionResolver) lookupDisplayName(ctx context.Context, token, name string) (TeamsMentionedUser, error) {
+	// OData string literals escape single quotes by doubling them
+	filter := fmt.Sprintf("displayName eq '%s'", strings.ReplaceAll(name, "'", "''"))
+	endpoint := graphAPIURL + "/users?" + url.Values{
+		"$filter": {filter},
+		"$select": {"id,displayName"},
+	}.Encode()
+
+	var result struct {
+		Value []graphUser `json:"value"`
+	}
+	if err := r.get(ctx, token, endpoint, &result); err != nil {
+		return TeamsMentionedUser{}, err
+	}
+	switch len(result.Value) {
+	case 0:
+		return TeamsMentionedUser{}, fmt.Errorf("no user named %q", name)
+	case 1:
+		return result.Value[0].mentioned(), nil
+	default:
+		return TeamsMentionedUser{}, fmt.Errorf("%d users named %q", len(result.Value), name)
+	}
+}
+
+// accessToken returns a cached app token, requesting a new one when expired.
+func (r *graphMentionResolver) accessToken(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.token != "" && r.now().Before(r.tokenExpiry) {
+		return r.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {r.clientID},
+		"client_secret": {r.clientSecret},
+		"scope":         {graphScope},
+	}
+	endpoint := graphLoginURL + "/" + url.PathEscape(r.tenantID) + "/oauth2/v2.0/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := r.do(req, &token); err != nil {
+		return "", fmt.Errorf("failed to get Graph token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("failed to get Graph token: empty access token")
+	}
+
+	r.token = token.AccessToken
+	r.tokenExpiry = r.now().Add(time.Duration(token.ExpiresIn)*time.Second - graphTokenExpirySkew)
+	return r.token, nil
+}
+
+func (r *graphMentionResolver) get(ctx context.Context, token, endpoint string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Graph request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return r.do(req, v)
+}
+
+func (r *graphMentionResolver) do(req *http.Request, v any) error {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("graph returned status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxGraphResponseBytes)).Decode(v)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// fakeMentionResolver resolves users from a fixed table.
+type fakeMentionResolver map[string]TeamsMentionedUser
+
+func (f fakeMentionResolver) ResolveMention(_ context.Context, user string) (TeamsMentionedUser, error) {
+	if mentioned, ok := f[user]; ok {
+		return mentioned, nil
+	}
+	return TeamsMentionedUser{}, errors.New("not found")
+}
+
+func graphResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestGraphMentionResolver(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	client := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			switch {
+			case req.URL.Host == "login.microsoftonline.com":
+				body, _ := io.ReadAll(req.Body)
+				if req.URL.Path != "/tenant-1/oauth2/v2.0/token" || !bytes.Contains(body, []byte("client_secret=s3cret")) {
+					return graphResponse(http.StatusUnauthorized, `{}`), nil
+				}
+				return graphResponse(http.StatusOK, `{"access_token":"tok","expires_in":3600}`), nil
+			case req.Header.Get("Authorization") != "Bearer tok":
+				return graphResponse(http.StatusUnauthorized, `{}`), nil
+			case req.URL.Path == "/v1.0/users/jane@contoso.com":
+				return graphResponse(http.StatusOK, `{"id":"aad-jane","displayName":"Jane Doe"}`), nil
+			case req.URL.Path == "/v1.0/users" && req.URL.Query().Get("$filter") == "displayName eq 'Sam O''Neil'":
+				return graphResponse(http.StatusOK, `{"value":[{"id":"aad-sam","displayName":"Sam O'Neil"}]}`), nil
+			case req.URL.Path == "/v1.0/users":
+				return graphResponse(http.StatusOK, `{"value":[]}`), nil
+			}
+			return graphResponse(http.StatusNotFound, `{}`), nil
+		},
+	}
+
+	r := newGraphMentionResolver(client, "tenant-1", "app", "s3cret")
+	ctx := context.Background()
+
+	jane, err := r.ResolveMention(ctx, "jane@contoso.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if jane != (TeamsMentionedUser{ID: "aad-jane", Name: "Jane Doe"}) {
+		t.Errorf("unexpected user: %+v", jane)
+	}
+
+	sam, err := r.ResolveMention(ctx, "Sam O'Neil")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sam.ID != "aad-sam" {
+		t.Errorf("unexpected user: %+v", sam)
+	}
+
+	if _, err := r.ResolveMention(ctx, "Nobody"); err == nil {
+		t.Error("expected error for unknown display name")
+	}
+
+	// Results and the token are cached
+	before := len(requests)
+	if _, err := r.ResolveMention(ctx, "jane@contoso.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != before {
+		t.Errorf("expected cached lookup, got requests %v", requests[before:])
+	}
+	tokenRequests := 0
+	for _, req := range requests {
+		if strings.HasSuffix(req, "/token") {
+			tokenRequests++
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("expected one token request, got %d", tokenRequests)
+	}
+}
+
+func TestResolveMentionsInEntities(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{
+		httpClient: recordingClient(&bodies),
+		mentionResolver: fakeMentionResolver{
+			"jane@contoso.com": {ID: "aad-jane", Name: "Jane Doe"},
+		},
+	}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":         "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"mention_users":       []any{"jane@contoso.com", "bob@contoso.com"},
+			"resolve_mentions":    true,
+			"graph_tenant_id":     "tenant-1",
+			"graph_client_id":     "app",
+			"graph_client_secret": "s3cret",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0", Branch: "main"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	card := decodeCard(t, bodies[0])
+	entities := card.MSTeams.Entities
+	if len(entities) != 2 {
+		t.Fatalf("expected 2 mention entities, got %d", len(entities))
+	}
+	if got := *entities[0].Mentioned; got != (TeamsMentionedUser{ID: "aad-jane", Name: "Jane Doe"}) {
+		t.Errorf("expected resolved user, got %+v", got)
+	}
+	if entities[0].Text != "<at>jane@contoso.com</at>" {
+		t.Errorf("expected mention text to match the card text, got %q", entities[0].Text)
+	}
+	// Failed lookups fall back to email-based mentions
+	if got := *entities[1].Mentioned; got != (TeamsMentionedUser{ID: "bob@contoso.com", Name: "bob@contoso.com"}) {
+		t.Errorf("expected email fallback, got %+v", got)
+	}
+}
+
+func TestMentionResolverUsesConfiguredClient(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{clients: newClientCache()}
+	cfg := p.parseConfig(map[string]any{
+		"proxy_url":               "http://proxy.corp:3128",
+		"request_timeout_seconds": 30,
+		"graph_tenant_id":         "tenant-configured-client",
+		"graph_client_id":         "app",
+		"graph_client_secret":     "s3cret",
+	})
+
+	resolver, err := p.getMentionResolver(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	configured, err := p.httpClientFor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, ok := resolver.(*graphMentionResolver).client.(*http.Client)
+	if !ok {
+		t.Fatalf("expected an *http.Client, got %T", resolver.(*graphMentionResolver).client)
+	}
+	if client.Transport != configured.(*http.Client).Transport || client.Timeout != 30*time.Second {
+		t.Errorf("expected the configured transport and timeout, got %+v", client)
+	}
+
+	redirect := &http.Request{URL: &url.URL{Scheme: "https", Host: "graph.microsoft.com", Path: "/v1.0/users"}}
+	if err := client.CheckRedirect(redirect, nil); err != nil {
+		t.Errorf("expected a redirect within Graph to be allowed, got %v", err)
+	}
+	redirect.URL.Host = "attacker.example.com"
+	if err := client.CheckRedirect(redirect, nil); err == nil {
+		t.Error("expected a redirect away from Graph to be refused")
+	}
+
+	if again, _ := p.getMentionResolver(cfg); again != resolver {
+		t.Error("expected the resolver to be reused for the same transport")
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Idempotency cache defaults.
+const (
+	DefaultIdempotencyCacheSize  = 1000
+	DefaultIdempotencyTTLSeconds = 3600
+)
+
+// lruCache is a concurrency-safe, size-bounded set of keys that expire after
+// a TTL. When full, the least recently used key is evicted.
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	order    *list.List // front is most recently used
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	expires time.Time
+}
+
+func newLRUCache(capacity int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// configure updates the capacity and TTL, evicting entries over the new capacity.
+func (c *lruCache) configure(capacity int, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity = capacity
+	c.ttl = ttl
+	c.evictLocked()
+}
+
+// contains reports whether key was added and has not expired.
+func (c *lruCache) contains(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	if !c.now().Before(elem.Value.(*lruEntry).expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return false
+	}
+	c.order.MoveToFront(elem)
+	return true
+}
+
+// add records key, refreshing its expiry if already present.
+func (c *lruCache) add(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, expires: expires})
+	c.evictLocked()
+}
+
+// len returns the number of cached keys, including expired ones not yet evicted.
+func (c *lruCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *lruCache) evictLocked() {
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// defaultIdempotencyCache is shared by plugin instances without an injected
+// cache, so duplicates are detected across hook invocations in one process.
+var defaultIdempotencyCache = newLRUCache(DefaultIdempotencyCacheSize, DefaultIdempotencyTTLSeconds*time.Second)
+
+// getIdempotencyCache returns the idempotency cache configured for cfg.
+func (p *TeamsPlugin) getIdempotencyCache(cfg *Config) *lruCache {
+	cache := p.idempotencyCache
+	if cache == nil {
+		cache = defaultIdempotencyCache
+	}
+	cache.configure(cfg.IdempotencyCacheSize, time.Duration(cfg.IdempotencyTTLSeconds)*time.Second)
+	return cache
+}
+
+// idempotencyKey identifies a notification: the same status and release sent
+// to the same webhook. It is hashed so webhook secrets are not kept in memory.
+func idempotencyKey(webhookURL string, n notification) string {
+	sum := sha256.Sum256([]byte(webhookURL + "\x00" + n.status + "\x00" + n.version))
+	return hex.EncodeToString(sum[:])
+}
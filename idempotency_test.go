@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLRUCacheEvictsAtCapacity(t *testing.T) {
+	t.Parallel()
+
+	c := newLRUCache(2, time.Hour)
+	c.add("a")
+	c.add("b")
+
+	// Touching "a" makes "b" the least recently used
+	if !c.contains("a") {
+		t.Fatal("expected a to be cached")
+	}
+	c.add("c")
+
+	if c.len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.len())
+	}
+	if c.contains("b") {
+		t.Error("expected b to be evicted")
+	}
+	if !c.contains("a") || !c.contains("c") {
+		t.Error("expected a and c to be cached")
+	}
+
+	c.configure(1, time.Hour)
+	if c.len() != 1 || !c.contains("c") {
+		t.Errorf("expected shrinking to keep only the most recent entry, got %d entries", c.len())
+	}
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	c := newLRUCache(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.add("a")
+	now = now.Add(59 * time.Second)
+	if !c.contains("a") {
+		t.Fatal("expected a to be cached before the TTL")
+	}
+	now = now.Add(time.Second)
+	if c.contains("a") {
+		t.Fatal("expected a to expire after the TTL")
+	}
+	if c.len() != 0 {
+		t.Errorf("expected expired entry to be removed, got %d entries", c.len())
+	}
+}
+
+func TestIdempotentSkipsDuplicates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	cache := newLRUCache(DefaultIdempotencyCacheSize, time.Hour)
+	cache.now = func() time.Time { return now }
+
+	var bodies [][]byte
+	p := &TeamsPlugin{
+		httpClient:       recordingClient(&bodies),
+		idempotencyCache: cache,
+	}
+	req := plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":             "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"idempotent":              true,
+			"idempotency_ttl_seconds": 60,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0", Branch: "main"},
+	}
+
+	send := func() *plugin.ExecuteResponse {
+		t.Helper()
+		resp, err := p.Execute(context.Background(), req)
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %+v", err, resp)
+		}
+		return resp
+	}
+
+	send()
+	if resp := send(); resp.Message != "Duplicate Teams success notification skipped" {
+		t.Errorf("unexpected message for duplicate: %q", resp.Message)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("expected one request, got %d", len(bodies))
+	}
+
+	// Once the TTL passes the notification can be sent again
+	now = now.Add(time.Minute)
+	send()
+	if len(bodies) != 2 {
+		t.Errorf("expected re-send after TTL, got %d requests", len(bodies))
+	}
+}
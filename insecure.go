@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// EnvAllowInsecure must be set to a true value for insecure_local_testing to
+// take effect. Requiring both means neither a stray config key nor a stray
+// environment variable can relax security hardening on its own.
+const EnvAllowInsecure = "TEAMS_ALLOW_INSECURE"
+
+// isLoopbackURL reports whether rawURL is an http or https URL for localhost
+// or a loopback address.
+func isLoopbackURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+	host := parsed.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// allowsInsecure reports whether webhookURL may bypass the HTTPS and
+// Microsoft host checks: only loopback URLs, and only with insecure local
+// testing enabled.
+func (cfg *Config) allowsInsecure(webhookURL string) bool {
+	return cfg.InsecureLocalTesting && isLoopbackURL(webhookURL)
+}
+
+// validateWebhookURL is validateTeamsWebhookURL, except that loopback URLs
+// are accepted when insecure local testing is enabled.
+func (cfg *Config) validateWebhookURL(webhookURL string) error {
+	if cfg.allowsInsecure(webhookURL) {
+		return nil
+	}
+	return validateTeamsWebhookURL(webhookURL)
+}
+
+// checkLocalRedirect is checkRedirect for insecure local testing: redirects
+// may also stay on loopback hosts over plain HTTP.
+func checkLocalRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 3 {
+		return fmt.Errorf("too many redirects")
+	}
+	if isLoopbackURL(req.URL.String()) {
+		return nil
+	}
+	return checkRedirect(req, via)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestIsLoopbackURL(t *testing.T) {
+	t.Parallel()
+
+	for rawURL, want := range map[string]bool{
+		"http://localhost:8080/hook":  true,
+		"https://LOCALHOST/hook":      true,
+		"http://127.0.0.1:9000/hook":  true,
+		"http://[::1]:9000/hook":      true,
+		"http://example.com/hook":     false,
+		"http://10.0.0.1/hook":        false,
+		"ftp://localhost/hook":        false,
+		"http://localhost.evil.com/x": false,
+	} {
+		if got := isLoopbackURL(rawURL); got != want {
+			t.Errorf("isLoopbackURL(%q) = %v, want %v", rawURL, got, want)
+		}
+	}
+}
+
+// The tests below set TEAMS_ALLOW_INSECURE, so they can't run in parallel.
+
+func TestValidateInsecureLocalTesting(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       string
+		insecure  bool
+		webhook   string
+		wantValid bool
+	}{
+		{name: "config_only", insecure: true, webhook: "http://localhost:8080/hook"},
+		{name: "env_only", env: "true", webhook: "http://localhost:8080/hook"},
+		{name: "both", env: "true", insecure: true, webhook: "http://localhost:8080/hook", wantValid: true},
+		{name: "env_false", env: "false", insecure: true, webhook: "http://localhost:8080/hook"},
+		{name: "remote_host_still_rejected", env: "true", insecure: true, webhook: "http://example.com/hook"},
+	}
+
+	p := &TeamsPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvAllowInsecure, tt.env)
+
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"webhook_url":            tt.webhook,
+				"insecure_local_testing": tt.insecure,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Errorf("expected Valid=%v, got %+v", tt.wantValid, resp.Errors)
+			}
+
+			warned := false
+			for _, e := range resp.Errors {
+				warned = warned || (e.Field == "insecure_local_testing" && isWarning(e))
+			}
+			if warned != tt.insecure {
+				t.Errorf("expected an insecure_local_testing warning=%v, got %+v", tt.insecure, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestInsecureLocalTestingSendsToLocalhost(t *testing.T) {
+	t.Setenv(EnvAllowInsecure, "true")
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhookURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/webhook"
+	config := map[string]any{
+		"webhook_url":            webhookURL,
+		"insecure_local_testing": true,
+		"verify_host_ip":         true,
+	}
+
+	p := &TeamsPlugin{}
+	if resp, _ := p.Validate(context.Background(), config); !resp.Valid {
+		t.Fatalf("expected a localhost webhook to validate, got %+v", resp.Errors)
+	}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected the local server to receive the card, got %d requests", hits.Load())
+	}
+}
+
+func TestInsecureLocalTestingTransport(t *testing.T) {
+	t.Setenv(EnvAllowInsecure, "true")
+
+	cfg := (&TeamsPlugin{}).parseConfig(map[string]any{"insecure_local_testing": true})
+	opts, err := transportOptionsFor(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := newHTTPClient(opts)
+	if minVersion := client.Transport.(*http.Transport).TLSClientConfig.MinVersion; minVersion != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2 to be allowed, got %x", minVersion)
+	}
+
+	local := &http.Request{URL: &url.URL{Scheme: "http", Host: "localhost:8080", Path: "/hook"}}
+	if err := client.CheckRedirect(local, nil); err != nil {
+		t.Errorf("expected a redirect to localhost to be allowed, got %v", err)
+	}
+	remote := &http.Request{URL: &url.URL{Scheme: "http", Host: "example.com"}}
+	if err := client.CheckRedirect(remote, nil); err == nil {
+		t.Error("expected a redirect to a remote HTTP host to be rejected")
+	}
+
+	if opts, _ := transportOptionsFor(&Config{}); !opts.isDefault() {
+		t.Error("expected the hardened default transport without insecure_local_testing")
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Handling of undefined variables accepted by env_undefined.
+const (
+	EnvUndefinedEmpty   = "empty"
+	EnvUndefinedLiteral = "literal"
+)
+
+// envRefPattern matches ${NAME} references in string config values.
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// interpolateConfig returns a copy of raw with ${NAME} references in string
+// values, including string lists, expanded from the process environment.
+// Undefined variables expand to "" or are kept literally per env_undefined.
+// Expanded values may hold secrets and must never be logged.
+func interpolateConfig(raw map[string]any) map[string]any {
+	keepUndefined := false
+	if mode, ok := raw["env_undefined"].(string); ok {
+		keepUndefined = strings.EqualFold(mode, EnvUndefinedLiteral)
+	}
+
+	expanded := make(map[string]any, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case string:
+			expanded[key] = expandEnvRefs(v, keepUndefined)
+		case []any:
+			items := make([]any, len(v))
+			for i, item := range v {
+				if s, ok := item.(string); ok {
+					items[i] = expandEnvRefs(s, keepUndefined)
+				} else {
+					items[i] = item
+				}
+			}
+			expanded[key] = items
+		case []string:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = expandEnvRefs(item, keepUndefined)
+			}
+			expanded[key] = items
+		default:
+			expanded[key] = value
+		}
+	}
+	return expanded
+}
+
+// expandEnvRefs expands ${NAME} references in s.
+func expandEnvRefs(s string, keepUndefined bool) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+		if keepUndefined {
+			return ref
+		}
+		return ""
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+// Tests in this file use t.Setenv and cannot run in parallel.
+
+func TestInterpolateConfig(t *testing.T) {
+	t.Setenv("TEAMS_TEST_BUILD_ID", "42")
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   string
+	}{
+		{
+			name:   "defined",
+			config: map[string]any{"title_template": "Release {{version}} (${TEAMS_TEST_BUILD_ID})"},
+			want:   "Release {{version}} (42)",
+		},
+		{
+			name:   "undefined_empty_by_default",
+			config: map[string]any{"title_template": "Release {{version}} (${TEAMS_TEST_UNDEFINED})"},
+			want:   "Release {{version}} ()",
+		},
+		{
+			name: "undefined_literal",
+			config: map[string]any{
+				"title_template": "Release {{version}} (${TEAMS_TEST_UNDEFINED})",
+				"env_undefined":  "literal",
+			},
+			want: "Release {{version}} (${TEAMS_TEST_UNDEFINED})",
+		},
+		{
+			name:   "bare_dollar_untouched",
+			config: map[string]any{"title_template": "Release $TEAMS_TEST_BUILD_ID costs $5"},
+			want:   "Release $TEAMS_TEST_BUILD_ID costs $5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := (&TeamsPlugin{}).parseConfig(tt.config)
+			if cfg.TitleTemplate != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, cfg.TitleTemplate)
+			}
+		})
+	}
+}
+
+func TestInterpolateConfigLists(t *testing.T) {
+	t.Setenv("TEAMS_TEST_ONCALL", "oncall@example.com")
+
+	cfg := (&TeamsPlugin{}).parseConfig(map[string]any{
+		"mention_users": []any{"${TEAMS_TEST_ONCALL}", "lead@example.com"},
+	})
+	if len(cfg.MentionUsers) != 2 || cfg.MentionUsers[0] != "oncall@example.com" {
+		t.Errorf("expected expanded mention, got %v", cfg.MentionUsers)
+	}
+}
+
+func TestInterpolateConfigDoesNotModifyInput(t *testing.T) {
+	t.Setenv("TEAMS_TEST_BUILD_ID", "42")
+
+	raw := map[string]any{"title_template": "${TEAMS_TEST_BUILD_ID}"}
+	_ = interpolateConfig(raw)
+	if raw["title_template"] != "${TEAMS_TEST_BUILD_ID}" {
+		t.Errorf("input config was modified: %v", raw["title_template"])
+	}
+}
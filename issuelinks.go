@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// issueRefPattern matches #N issue references and !N GitLab merge request
+// references. The leading group keeps "&#39;"-style HTML entities and
+// references inside words, such as "abc#1", from matching.
+var issueRefPattern = regexp.MustCompile(`(^|[^&\w])([#!])(\d+)\b`)
+
+// issueLinkPaths returns the paths issue and merge/pull request numbers are
+// appended to for a repository web URL. GitLab nests them under "/-/";
+// GitHub and similar hosts redirect /issues/N to the pull request when N is
+// one, and have no !N references.
+func issueLinkPaths(repoURL string) (issues, mergeRequests string) {
+	parsed, err := url.Parse(repoURL)
+	if err != nil || parsed.Host == "" {
+		return "", ""
+	}
+	if strings.Contains(strings.ToLower(parsed.Hostname()), "gitlab") {
+		return repoURL + "/-/issues/", repoURL + "/-/merge_requests/"
+	}
+	return repoURL + "/issues/", ""
+}
+
+// linkifyIssues turns #N references in already escaped markdown text into
+// links to the repository's issues (and !N into GitLab merge requests).
+// Text inside `code spans` is left alone, as is everything when repoURL is
+// empty.
+func linkifyIssues(text, repoURL string) string {
+	issues, mergeRequests := issueLinkPaths(repoURL)
+	if issues == "" {
+		return text
+	}
+
+	// Odd segments are inside code spans, except text after an unclosed backtick
+	segments := strings.Split(text, "`")
+	for i := range segments {
+		if i%2 == 1 && i < len(segments)-1 {
+			continue
+		}
+		segments[i] = issueRefPattern.ReplaceAllStringFunc(segments[i], func(match string) string {
+			m := issueRefPattern.FindStringSubmatch(match)
+			base := issues
+			if m[2] == "!" {
+				base = mergeRequests
+			}
+			if base == "" {
+				return match
+			}
+			return fmt.Sprintf("%s[%s%s](%s%s)", m[1], m[2], m[3], base, m[3])
+		})
+	}
+	return strings.Join(segments, "`")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"html"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLinkifyIssues(t *testing.T) {
+	t.Parallel()
+
+	const github = "https://github.com/acme/api"
+	const gitlab = "https://gitlab.com/acme/api"
+
+	tests := []struct {
+		name    string
+		text    string
+		repoURL string
+		want    string
+	}{
+		{name: "github_issue", text: "fix login (#123)", repoURL: github, want: "fix login ([#123](https://github.com/acme/api/issues/123))"},
+		{name: "several", text: "#1, #2", repoURL: github, want: "[#1](https://github.com/acme/api/issues/1), [#2](https://github.com/acme/api/issues/2)"},
+		{name: "gitlab_issue", text: "closes #7", repoURL: gitlab, want: "closes [#7](https://gitlab.com/acme/api/-/issues/7)"},
+		{name: "gitlab_merge_request", text: "see !42", repoURL: gitlab, want: "see [!42](https://gitlab.com/acme/api/-/merge_requests/42)"},
+		{name: "github_has_no_merge_requests", text: "wow!42", repoURL: github, want: "wow!42"},
+		{name: "no_repository", text: "fix login (#123)", want: "fix login (#123)"},
+		{name: "code_span", text: "use `#123` literally, see #4", repoURL: github, want: "use `#123` literally, see [#4](https://github.com/acme/api/issues/4)"},
+		{name: "unclosed_backtick", text: "a ` b #5", repoURL: github, want: "a ` b [#5](https://github.com/acme/api/issues/5)"},
+		{name: "html_entity", text: html.EscapeString("don't break #8"), repoURL: github, want: "don&#39;t break [#8](https://github.com/acme/api/issues/8)"},
+		{name: "inside_word", text: "abc#9 and color#fff", repoURL: github, want: "abc#9 and color#fff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := linkifyIssues(tt.text, tt.repoURL); got != tt.want {
+				t.Errorf("linkifyIssues(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkifyIssuesRendered(t *testing.T) {
+	t.Parallel()
+
+	changes := &plugin.CategorizedChanges{
+		Fixes: []plugin.ConventionalCommit{{Description: "fix login (#123)"}},
+	}
+	cfg := &Config{GroupByScope: true, LinkifyIssues: true}
+
+	tests := []struct {
+		name          string
+		repositoryURL string
+		want          string
+	}{
+		{name: "github", repositoryURL: "git@github.com:acme/api.git", want: "- fix login ([#123](https://github.com/acme/api/issues/123))"},
+		{name: "no_repository", want: "- fix login (#123)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := (&TeamsPlugin{}).buildSuccessNotification(cfg, plugin.ReleaseContext{
+				Version:       "1.0.0",
+				RepositoryURL: tt.repositoryURL,
+				Changes:       changes,
+			})
+			found := false
+			for _, elem := range n.body {
+				found = found || elem.Text == tt.want
+			}
+			if !found {
+				t.Errorf("expected a change entry %q", tt.want)
+			}
+		})
+	}
+}
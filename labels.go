@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// Label keys accepted by the labels config.
+const (
+	LabelVersion      = "version"
+	LabelType         = "type"
+	LabelBranch       = "branch"
+	LabelTag          = "tag"
+	LabelApprovedBy   = "approved_by"
+	LabelChanges      = "changes"
+	LabelContributors = "contributors"
+	LabelCIProvider   = "ci_provider"
+	LabelPrevious     = "previous_version"
+)
+
+// defaultLabels are the card labels used unless overridden by labels.
+var defaultLabels = map[string]string{
+	LabelVersion:      "Version",
+	LabelType:         "Type",
+	LabelBranch:       "Branch",
+	LabelTag:          "Tag",
+	LabelApprovedBy:   "Approved by",
+	LabelChanges:      "Changes",
+	LabelContributors: "Contributors",
+	LabelCIProvider:   "Triggered by",
+	LabelPrevious:     "Upgrade",
+}
+
+// label returns the configured label for key, falling back to the default.
+func (cfg *Config) label(key string) string {
+	if l := cfg.Labels[key]; l != "" {
+		return l
+	}
+	return defaultLabels[key]
+}
+
+// parseLabels reads the labels map, lowercasing keys and skipping blanks.
+func parseLabels(raw map[string]any) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if s, ok := value.(string); ok && strings.TrimSpace(s) != "" {
+			labels[strings.ToLower(key)] = strings.TrimSpace(s)
+		}
+	}
+	return labels
+}
+
+// validateLabels rejects label keys that don't match a card label.
+func validateLabels(vb *helpers.ValidationBuilder, raw map[string]any) {
+	known := make([]string, 0, len(defaultLabels))
+	for key := range defaultLabels {
+		known = append(known, key)
+	}
+	sort.Strings(known)
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, ok := defaultLabels[strings.ToLower(key)]; !ok {
+			vb.AddErrorWithCode("labels",
+				fmt.Sprintf("labels key %q must be one of: %s", key, strings.Join(known, ", ")),
+				"format")
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestLabelsOverride(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"labels":      map[string]any{"Version": "Ver", "branch": "Git Branch", "changes": "Änderungen", "tag": " "},
+	})
+
+	n := p.buildSuccessNotification(cfg, plugin.ReleaseContext{
+		Version:     "1.0.0",
+		ReleaseType: "minor",
+		Branch:      "main",
+		TagName:     "v1.0.0",
+		Changes:     &plugin.CategorizedChanges{},
+	})
+
+	facts := infoFacts(n.body)
+	want := map[string]string{
+		"Ver:":        "1.0.0",
+		"Git Branch:": "main",
+		"Type:":       "Minor",
+		"Tag:":        "v1.0.0",
+	}
+	for label, value := range want {
+		if facts[label] != value {
+			t.Errorf("expected %s %q, got facts %v", label, value, facts)
+		}
+	}
+	if _, ok := facts["Version:"]; ok {
+		t.Error("overridden default label still rendered")
+	}
+
+	if summary := n.body[2].Text; summary != "Änderungen: 0 features, 0 fixes" {
+		t.Errorf("unexpected changes summary: %q", summary)
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"labels":      map[string]any{"version": "Ver", "colour": "Farbe"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || len(resp.Errors) != 1 || resp.Errors[0].Field != "labels" {
+		t.Errorf("expected a single labels error, got %+v", resp.Errors)
+	}
+}
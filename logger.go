@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Logger is the logging interface used by the plugin.
+// Arguments after the message are alternating key/value pairs, matching the
+// convention used by hclog so the host's logger can be injected directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// noopLogger discards all log output.
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any) {}
+func (noopLogger) Info(string, ...any)  {}
+func (noopLogger) Warn(string, ...any)  {}
+func (noopLogger) Error(string, ...any) {}
+
+// getLogger returns the logger to use.
+func (p *TeamsPlugin) getLogger() Logger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return noopLogger{}
+}
+
+// redactWebhookURL reduces a webhook URL to its scheme and host.
+// Webhook paths and query strings embed secrets and must never be logged.
+func redactWebhookURL(webhookURL string) string {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Host == "" {
+		return "[redacted]"
+	}
+	return parsed.Scheme + "://" + parsed.Host + "/[redacted]"
+}
+
+// redactError returns the error message with any occurrence of the webhook URL redacted.
+func redactError(err error, webhookURL string) string {
+	if err == nil {
+		return ""
+	}
+	msg := err.Error()
+	if webhookURL != "" {
+		msg = strings.ReplaceAll(msg, webhookURL, redactWebhookURL(webhookURL))
+	}
+	return msg
+}
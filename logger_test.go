@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// logEntry is a single captured log call.
+type logEntry struct {
+	Level string
+	Msg   string
+	Args  []any
+}
+
+// captureLogger records log calls for assertions.
+type captureLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *captureLogger) record(level, msg string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{Level: level, Msg: msg, Args: args})
+}
+
+func (l *captureLogger) Debug(msg string, args ...any) { l.record("debug", msg, args) }
+func (l *captureLogger) Info(msg string, args ...any)  { l.record("info", msg, args) }
+func (l *captureLogger) Warn(msg string, args ...any)  { l.record("warn", msg, args) }
+func (l *captureLogger) Error(msg string, args ...any) { l.record("error", msg, args) }
+
+// find returns the first entry with the given level and message.
+func (l *captureLogger) find(level, msg string) (logEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.entries {
+		if e.Level == level && e.Msg == msg {
+			return e, true
+		}
+	}
+	return logEntry{}, false
+}
+
+// dump renders all entries as a single string.
+func (l *captureLogger) dump() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var sb strings.Builder
+	for _, e := range l.entries {
+		fmt.Fprintf(&sb, "%s %s %v\n", e.Level, e.Msg, e.Args)
+	}
+	return sb.String()
+}
+
+const testWebhookSecretPath = "/webhookb2/secret-token/IncomingWebhook/456/789"
+
+func TestLoggerEvents(t *testing.T) {
+	t.Parallel()
+
+	webhook := "https://example.webhook.office.com" + testWebhookSecretPath
+
+	tests := []struct {
+		name       string
+		doFunc     func(req *http.Request) (*http.Response, error)
+		wantEvents []logEntry
+	}{
+		{
+			name: "success",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+			},
+			wantEvents: []logEntry{
+				{Level: "debug", Msg: "config parsed"},
+				{Level: "debug", Msg: "card built"},
+				{Level: "debug", Msg: "sending Teams message"},
+				{Level: "info", Msg: "Teams message sent"},
+			},
+		},
+		{
+			name: "status_failure",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+			},
+			wantEvents: []logEntry{
+				{Level: "debug", Msg: "sending Teams message"},
+				{Level: "error", Msg: "Teams message failed"},
+			},
+		},
+		{
+			name: "network_failure",
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, fmt.Errorf("Post %q: %w", req.URL.String(), errors.New("connection refused"))
+			},
+			wantEvents: []logEntry{
+				{Level: "error", Msg: "Teams message failed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &captureLogger{}
+			p := &TeamsPlugin{
+				httpClient: &MockHTTPClient{DoFunc: tt.doFunc},
+				Logger:     logger,
+			}
+
+			_, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  map[string]any{"webhook_url": webhook, "max_retries": 0},
+				Context: plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, want := range tt.wantEvents {
+				if _, ok := logger.find(want.Level, want.Msg); !ok {
+					t.Errorf("expected %s event %q, got:\n%s", want.Level, want.Msg, logger.dump())
+				}
+			}
+
+			if strings.Contains(logger.dump(), "secret-token") {
+				t.Errorf("expected webhook secret to be redacted from logs, got:\n%s", logger.dump())
+			}
+		})
+	}
+}
+
+func TestRedactWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "webhook_office_com",
+			url:  "https://example.webhook.office.com" + testWebhookSecretPath,
+			want: "https://example.webhook.office.com/[redacted]",
+		},
+		{
+			name: "empty",
+			url:  "",
+			want: "[redacted]",
+		},
+		{
+			name: "unparseable",
+			url:  "://bad",
+			want: "[redacted]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactWebhookURL(tt.url); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetLoggerDefaultsToNoop(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	if _, ok := p.getLogger().(noopLogger); !ok {
+		t.Errorf("expected noopLogger by default, got %T", p.getLogger())
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Teams TextBlocks render a markdown subset: bold, italic, bulleted and
+// numbered lists, and links. sanitizeMarkdown keeps those and reduces
+// everything else to plain text, so render_markdown can't smuggle HTML,
+// remote images or script links into a card.
+
+var (
+	mdAutolinkPattern = regexp.MustCompile(`<(https?://[^\s<>]+)>`)
+	mdCommentPattern  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	mdHTMLTagPattern  = regexp.MustCompile(`</?[a-zA-Z][^<>]*>`)
+	mdLinkPattern     = regexp.MustCompile(`(!?)\[([^\[\]]*)\]\(\s*((?:[^()\s]|\([^()\s]*\))*)(?:\s+"[^"]*")?\s*\)`)
+	mdHeadingPattern  = regexp.MustCompile(`^ {0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	mdFencePattern    = regexp.MustCompile("^ {0,3}(```|~~~)")
+)
+
+// sanitizeMarkdown prepares release notes for a markdown TextBlock. HTML
+// tags and comments are removed, images become their alt text, links keep
+// only http, https and mailto targets, and headings (unsupported in Teams)
+// become bold lines.
+func sanitizeMarkdown(text string) string {
+	text = mdCommentPattern.ReplaceAllString(text, "")
+	text = mdAutolinkPattern.ReplaceAllString(text, "[$1]($1)")
+	text = mdHTMLTagPattern.ReplaceAllString(text, "")
+	text = strings.NewReplacer("<", "&lt;", ">", "&gt;").Replace(text)
+
+	text = mdLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		m := mdLinkPattern.FindStringSubmatch(link)
+		image, label, target := m[1] != "", m[2], m[3]
+		if image || !isSafeLinkTarget(target) {
+			return label
+		}
+		return "[" + label + "](" + target + ")"
+	})
+
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		// Code fences have no rendering; keep the code as plain lines
+		if mdFencePattern.MatchString(line) {
+			continue
+		}
+		if m := mdHeadingPattern.FindStringSubmatch(line); m != nil {
+			if m[1] == "" {
+				continue
+			}
+			line = "**" + m[1] + "**"
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// isSafeLinkTarget reports whether a markdown link may point at target.
+func isSafeLinkTarget(target string) bool {
+	lower := strings.ToLower(target)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "mailto:")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "bullets_and_bold", text: "- **api**: fix login\n* add export\n1. first", want: "- **api**: fix login\n* add export\n1. first"},
+		{name: "link", text: "see [#12](https://github.com/acme/api/pull/12?a=1&b=2)", want: "see [#12](https://github.com/acme/api/pull/12?a=1&b=2)"},
+		{name: "mailto_link", text: "[security](mailto:sec@acme.dev)", want: "[security](mailto:sec@acme.dev)"},
+		{name: "script_link", text: "[click](javascript:alert(1))", want: "click"},
+		{name: "data_link", text: "[x](data:text/html;base64,PHNjcmlwdD4=)", want: "x"},
+		{name: "image", text: "![tracking pixel](https://evil.example/p.png)", want: "tracking pixel"},
+		{name: "html_tags", text: "<script>alert(1)</script> <b>bold</b><br/>", want: "alert(1) bold"},
+		{name: "comment", text: "a<!-- hidden\nnote -->b", want: "ab"},
+		{name: "autolink", text: "<https://acme.dev/docs>", want: "[https://acme.dev/docs](https://acme.dev/docs)"},
+		{name: "stray_angle_brackets", text: "a < b > c", want: "a &lt; b &gt; c"},
+		{name: "heading", text: "## Features ##\n- one", want: "**Features**\n- one"},
+		{name: "code_fence", text: "```sh\nnpm i acme\n```", want: "npm i acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := sanitizeMarkdown(tt.text); got != tt.want {
+				t.Errorf("sanitizeMarkdown(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	const notes = "## Fixes\n- fix <b>login</b> ([#12](https://github.com/acme/api/pull/12))"
+
+	tests := []struct {
+		name     string
+		markdown bool
+		want     string
+	}{
+		{name: "disabled_escapes", want: "## Fixes\n- fix &lt;b&gt;login&lt;/b&gt; ([#12](https://github.com/acme/api/pull/12))"},
+		{name: "enabled_sanitizes", markdown: true, want: "**Fixes**\n- fix login ([#12](https://github.com/acme/api/pull/12))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"webhook_url":     "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"render_markdown": tt.markdown,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: notes},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			card := string(bodies[0])
+			var found *AdaptiveElement
+			for _, elem := range decodeCard(t, bodies[0]).Body {
+				if strings.Contains(elem.Text, "login") {
+					found = &elem
+				}
+			}
+			if found == nil {
+				t.Fatalf("expected a release notes block in %s", card)
+			}
+			if found.Text != tt.want || !found.Wrap {
+				t.Errorf("expected wrapped notes %q, got %q (wrap=%v)", tt.want, found.Text, found.Wrap)
+			}
+		})
+	}
+}
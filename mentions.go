@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// mentionChunkInterval is the pause between cards when mentions are chunked,
+// keeping bursts under the incoming webhook rate limit.
+const mentionChunkInterval = time.Second
+
+// mentionTag returns the <at> markup used for a mention in both the entity
+// and the display text, which Teams requires to match exactly.
+func mentionTag(user string) string {
+	return fmt.Sprintf("<at>%s</at>", user)
+}
+
+// isValidMention reports whether a mention user can be safely embedded in <at> markup.
+func isValidMention(user string) bool {
+	if user == "" || strings.ContainsAny(user, "<>") {
+		return false
+	}
+	for _, r := range user {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeMentions trims mention users and splits them into valid and dropped entries.
+func sanitizeMentions(users []string) (valid, dropped []string) {
+	for _, user := range users {
+		trimmed := strings.TrimSpace(user)
+		if isValidMention(trimmed) {
+			valid = append(valid, trimmed)
+		} else {
+			dropped = append(dropped, user)
+		}
+	}
+	return valid, dropped
+}
+
+// sanitizeConfigMentions drops invalid mention users from cfg, logging each one.
+func (p *TeamsPlugin) sanitizeConfigMentions(cfg *Config) {
+	valid, dropped := sanitizeMentions(cfg.MentionUsers)
+	for _, user := range dropped {
+		p.getLogger().Warn("dropping invalid mention", "user", fmt.Sprintf("%q", user))
+	}
+	cfg.MentionUsers = valid
+}
+
+// mentionGroups splits the configured mentions into per-card groups.
+// Without a cap there is a single group. Over the cap, extras are dropped
+// unless chunking is enabled, in which case each group holds at most
+// MaxMentions users. There is always at least one group.
+func (p *TeamsPlugin) mentionGroups(cfg *Config) [][]string {
+	users := cfg.MentionUsers
+	limit := cfg.MaxMentions
+	if limit <= 0 || len(users) <= limit {
+		return [][]string{users}
+	}
+
+	if !cfg.ChunkMentions {
+		p.getLogger().Warn("dropping mentions over max_mentions", "max_mentions", limit, "dropped", len(users)-limit)
+		return [][]string{users[:limit]}
+	}
+
+	groups := make([][]string, 0, (len(users)+limit-1)/limit)
+	for start := 0; start < len(users); start += limit {
+		end := min(start+limit, len(users))
+		groups = append(groups, users[start:end])
+	}
+	return groups
+}
+
+// muteMentions reports whether mention_only_on_breaking drops the mentions
+// of a notification: a success without breaking changes.
+func (cfg *Config) muteMentions(n notification) bool {
+	if !cfg.MentionOnlyOnBreaking || n.status != StatusSuccess {
+		return false
+	}
+	return n.release.Changes == nil || len(n.release.Changes.Breaking) == 0
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSanitizeMentions(t *testing.T) {
+	t.Parallel()
+
+	valid, dropped := sanitizeMentions([]string{
+		" alice@example.com ",
+		"foo<at>bar@example.com",
+		"evil</at><at>admin@example.com",
+		"tab\tuser@example.com",
+		"",
+		"bob@example.com",
+	})
+
+	wantValid := []string{"alice@example.com", "bob@example.com"}
+	if strings.Join(valid, ",") != strings.Join(wantValid, ",") {
+		t.Errorf("expected valid %v, got %v", wantValid, valid)
+	}
+	if len(dropped) != 4 {
+		t.Errorf("expected 4 dropped mentions, got %d: %q", len(dropped), dropped)
+	}
+}
+
+func TestMaliciousMentionIsDropped(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	logger := &captureLogger{}
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies), Logger: logger}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnError,
+		Config: map[string]any{
+			"webhook_url":   "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"mention_users": []any{"ok@example.com", "foo<at>bar@example.com"},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+	}
+
+	payload := string(bodies[0])
+	if strings.Contains(payload, "foo") {
+		t.Errorf("expected malicious mention to be dropped, payload: %s", payload)
+	}
+
+	card := decodeCard(t, bodies[0])
+	if card.MSTeams == nil || len(card.MSTeams.Entities) != 1 {
+		t.Fatalf("expected exactly one mention entity, got %+v", card.MSTeams)
+	}
+	if card.MSTeams.Entities[0].Text != "<at>ok@example.com</at>" {
+		t.Errorf("unexpected entity text %q", card.MSTeams.Entities[0].Text)
+	}
+
+	if _, ok := logger.find("warn", "dropping invalid mention"); !ok {
+		t.Errorf("expected dropped mention to be reported, got:\n%s", logger.dump())
+	}
+}
+
+func TestAllInvalidMentionsOmitMentionBlock(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{"foo<at>bar@example.com", "tab\tuser@example.com", ""}
+
+	// Entries dropped during config resolution
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnError,
+		Config: map[string]any{
+			"webhook_url":   "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"mention_users": []any{invalid[0], invalid[1], invalid[2]},
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+	}
+
+	// Entries passed straight to the message builders
+	direct := p.buildNotificationMessage(&Config{}, p.buildErrorNotification(&Config{}, plugin.ReleaseContext{Version: "1.0.0"}), invalid)
+
+	for name, card := range map[string]AdaptiveCard{
+		"execute": decodeCard(t, bodies[0]),
+		"direct":  direct.Attachments[0].Content,
+	} {
+		if card.MSTeams != nil {
+			t.Errorf("%s: expected no msteams config, got %+v", name, card.MSTeams)
+		}
+		for _, elem := range card.Body {
+			if strings.HasPrefix(elem.Text, "cc:") {
+				t.Errorf("%s: expected no mention block, got %q", name, elem.Text)
+			}
+		}
+	}
+}
+
+func TestValidateRejectsMalformedMentions(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url":   "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"mention_users": []any{"ok@example.com", "a<b>@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || resp.Errors[0].Field != "mention_users" {
+		t.Errorf("expected mention_users format error, got %+v", resp.Errors)
+	}
+}
+
+func TestChunkedMentions(t *testing.T) {
+	t.Parallel()
+
+	users := make([]any, 30)
+	for i := range users {
+		users[i] = fmt.Sprintf("user%02d@example.com", i)
+	}
+
+	tests := []struct {
+		name      string
+		chunk     bool
+		wantCards int
+		wantTotal int
+	}{
+		{name: "chunked", chunk: true, wantCards: 3, wantTotal: 30},
+		{name: "capped_without_chunking", chunk: false, wantCards: 1, wantTotal: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies [][]byte
+			var delays []time.Duration
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies), sleepFunc: recordSleeps(&delays)}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"webhook_url":    "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"mention_users":  users,
+					"max_mentions":   10,
+					"chunk_mentions": tt.chunk,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+			}
+
+			if len(bodies) != tt.wantCards {
+				t.Fatalf("expected %d cards, got %d", tt.wantCards, len(bodies))
+			}
+			if len(delays) != tt.wantCards-1 {
+				t.Errorf("expected %d rate-limit pauses, got %d", tt.wantCards-1, len(delays))
+			}
+
+			seen := map[string]bool{}
+			for i, body := range bodies {
+				card := decodeCard(t, body)
+				if card.MSTeams == nil || len(card.MSTeams.Entities) > 10 {
+					t.Fatalf("card %d: expected at most 10 mentions, got %+v", i, card.MSTeams)
+				}
+				if card.Body[0].Text != "Release 1.0.0" {
+					t.Errorf("card %d: expected identical card content, got header %q", i, card.Body[0].Text)
+				}
+				for _, e := range card.MSTeams.Entities {
+					seen[e.Mentioned.ID] = true
+				}
+			}
+			if len(seen) != tt.wantTotal {
+				t.Errorf("expected %d distinct mentions delivered, got %d", tt.wantTotal, len(seen))
+			}
+		})
+	}
+}
+
+func TestMentionOnlyOnBreaking(t *testing.T) {
+	t.Parallel()
+
+	breaking := &plugin.CategorizedChanges{
+		Breaking: []plugin.ConventionalCommit{{Description: "drop v1 API", Breaking: true}},
+	}
+	routine := &plugin.CategorizedChanges{
+		Fixes: []plugin.ConventionalCommit{{Description: "fix login"}},
+	}
+
+	tests := []struct {
+		name        string
+		hook        plugin.Hook
+		changes     *plugin.CategorizedChanges
+		onlyOnBreak bool
+		wantMention bool
+	}{
+		{name: "breaking_success", hook: plugin.HookPostPublish, changes: breaking, onlyOnBreak: true, wantMention: true},
+		{name: "routine_success", hook: plugin.HookPostPublish, changes: routine, onlyOnBreak: true},
+		{name: "no_changes_success", hook: plugin.HookPostPublish, onlyOnBreak: true},
+		{name: "error_still_mentions", hook: plugin.HookOnError, changes: routine, onlyOnBreak: true, wantMention: true},
+		{name: "disabled", hook: plugin.HookPostPublish, changes: routine, wantMention: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: tt.hook,
+				Config: map[string]any{
+					"webhook_url":              "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"mention_users":            []any{"oncall@example.com"},
+					"mention_only_on_breaking": tt.onlyOnBreak,
+				},
+				Context: plugin.ReleaseContext{Version: "2.0.0", Changes: tt.changes},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			card := decodeCard(t, bodies[0])
+			mentioned := card.MSTeams != nil && len(card.MSTeams.Entities) > 0
+			if mentioned != tt.wantMention {
+				t.Errorf("expected mention=%v, got %+v", tt.wantMention, card.MSTeams)
+			}
+			if !tt.wantMention && strings.Contains(string(bodies[0]), "<at>") {
+				t.Errorf("expected no mention text in the card")
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// MetadataElementID is the id of the hidden card element carrying the
+// machine-readable release summary added by embed_metadata.
+const MetadataElementID = "relicta-release-metadata"
+
+// ReleaseMetadata is the compact release summary embedded for channel bots.
+type ReleaseMetadata struct {
+	Status   string `json:"status"`
+	Version  string `json:"version"`
+	Tag      string `json:"tag,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Features int    `json:"features"`
+	Fixes    int    `json:"fixes"`
+	Breaking int    `json:"breaking"`
+}
+
+// newReleaseMetadata summarizes a release for embedding.
+func newReleaseMetadata(status string, releaseCtx plugin.ReleaseContext) ReleaseMetadata {
+	meta := ReleaseMetadata{
+		Status:  status,
+		Version: releaseCtx.Version,
+		Tag:     releaseCtx.TagName,
+		Type:    normalizeReleaseType(releaseCtx.ReleaseType),
+	}
+	if releaseCtx.Changes != nil {
+		meta.Features = len(releaseCtx.Changes.Features)
+		meta.Fixes = len(releaseCtx.Changes.Fixes)
+		meta.Breaking = len(releaseCtx.Changes.Breaking)
+	}
+	return meta
+}
+
+// buildMetadataElement renders the summary as JSON in a hidden TextBlock.
+// Teams keeps invisible elements in the stored card, so bots reading the
+// message can find it by MetadataElementID.
+func buildMetadataElement(meta ReleaseMetadata) AdaptiveElement {
+	// Marshaling a struct of strings and ints cannot fail
+	data, _ := json.Marshal(meta)
+	hidden := false
+	return AdaptiveElement{
+		Type:      "TextBlock",
+		ID:        MetadataElementID,
+		Text:      string(data),
+		IsVisible: &hidden,
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// findMetadata returns the embedded release metadata element, if any.
+func findMetadata(card AdaptiveCard) (AdaptiveElement, bool) {
+	for _, elem := range card.Body {
+		if elem.ID == MetadataElementID {
+			return elem, true
+		}
+	}
+	return AdaptiveElement{}, false
+}
+
+func TestEmbedMetadataRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":    "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"embed_metadata": true,
+		},
+		Context: plugin.ReleaseContext{
+			Version:     "2.0.0",
+			TagName:     "v2.0.0",
+			ReleaseType: "Major",
+			Branch:      "main",
+			Changes: &plugin.CategorizedChanges{
+				Features: []plugin.ConventionalCommit{{Description: "a"}, {Description: "b"}},
+				Fixes:    []plugin.ConventionalCommit{{Description: "c"}},
+				Breaking: []plugin.ConventionalCommit{{Description: "d"}},
+			},
+		},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	elem, ok := findMetadata(decodeCard(t, bodies[0]))
+	if !ok {
+		t.Fatal("expected metadata element")
+	}
+	if elem.IsVisible == nil || *elem.IsVisible {
+		t.Error("expected metadata element to be hidden")
+	}
+
+	var got ReleaseMetadata
+	if err := json.Unmarshal([]byte(elem.Text), &got); err != nil {
+		t.Fatalf("metadata is not valid JSON: %v", err)
+	}
+	want := ReleaseMetadata{
+		Status:   StatusSuccess,
+		Version:  "2.0.0",
+		Tag:      "v2.0.0",
+		Type:     "major",
+		Features: 2,
+		Fixes:    1,
+		Breaking: 1,
+	}
+	if got != want {
+		t.Errorf("metadata = %+v, want %+v", got, want)
+	}
+}
+
+func TestEmbedMetadataDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	n := (&TeamsPlugin{}).buildSuccessNotification(&Config{}, plugin.ReleaseContext{Version: "1.0.0"})
+	for _, elem := range n.body {
+		if elem.ID == MetadataElementID {
+			t.Fatal("expected no metadata element by default")
+		}
+	}
+}
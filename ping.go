@@ -0,0 +1,22 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// pingText is the body of the card sent by Ping.
+const pingText = "Relicta Teams plugin connectivity check"
+
+// Ping sends a minimal card to the webhook and reports whether Teams accepted
+// it. It is intended for health checks and connectivity validation.
+func (p *TeamsPlugin) Ping(ctx context.Context, webhookURL string) error {
+	if err := validateTeamsWebhookURL(webhookURL); err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+
+	msg := p.buildTeamsMessage([]AdaptiveElement{
+		{Type: "TextBlock", Text: pingText, Wrap: true},
+	}, nil, nil, "")
+	return p.sendMessage(ctx, webhookURL, msg)
+}
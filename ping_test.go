@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+
+	t.Run("posts_minimal_card", func(t *testing.T) {
+		t.Parallel()
+
+		var bodies [][]byte
+		p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+		if err := p.Ping(context.Background(), webhook); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(bodies) != 1 {
+			t.Fatalf("expected one request, got %d", len(bodies))
+		}
+		card := decodeCard(t, bodies[0])
+		if card.Type != "AdaptiveCard" || card.Version != AdaptiveCardVersion {
+			t.Errorf("unexpected card header: %s %s", card.Type, card.Version)
+		}
+		if len(card.Body) != 1 || card.Body[0].Text != pingText {
+			t.Errorf("unexpected card body: %+v", card.Body)
+		}
+		if len(card.Actions) != 0 || card.MSTeams != nil {
+			t.Error("expected a minimal card without actions or mentions")
+		}
+	})
+
+	t.Run("non_200_is_error", func(t *testing.T) {
+		t.Parallel()
+
+		p := &TeamsPlugin{httpClient: &MockHTTPClient{
+			DoFunc: func(*http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+			},
+		}}
+		err := p.Ping(context.Background(), webhook)
+		var se *statusError
+		if !errors.As(err, &se) || se.StatusCode != http.StatusNotFound {
+			t.Errorf("expected 404 status error, got %v", err)
+		}
+	})
+
+	t.Run("rejects_invalid_url", func(t *testing.T) {
+		t.Parallel()
+
+		p := &TeamsPlugin{httpClient: &MockHTTPClient{
+			DoFunc: func(*http.Request) (*http.Response, error) {
+				t.Error("no request expected for an invalid URL")
+				return nil, errors.New("unexpected request")
+			},
+		}}
+		if err := p.Ping(context.Background(), "http://evil.example.com/hook"); err == nil {
+			t.Error("expected an error for a non-Teams URL")
+		}
+	})
+}
@@ -4,20 +4,24 @@ package main
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
-	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
 )
 
@@ -28,60 +32,338 @@ type HTTPClient interface {
 
 // Shared HTTP client for connection reuse across requests.
 // Includes security hardening: TLS 1.3+, redirect protection, SSRF prevention.
-var defaultHTTPClient HTTPClient = &http.Client{
-	Timeout: 10 * time.Second,
-	CheckRedirect: func(req *http.Request, via []*http.Request) error {
-		// Limit redirect chain length
-		if len(via) >= 3 {
-			return fmt.Errorf("too many redirects")
-		}
-		// Prevent redirect to non-HTTPS
-		if req.URL.Scheme != "https" {
-			return fmt.Errorf("redirect to non-HTTPS URL not allowed")
-		}
-		// Prevent redirect away from Microsoft domains (SSRF protection)
-		if !isValidMicrosoftHost(req.URL.Host) {
-			return fmt.Errorf("redirect away from Microsoft domains not allowed")
-		}
-		return nil
-	},
-	Transport: &http.Transport{
-		MaxIdleConns:        10,
-		MaxIdleConnsPerHost: 5,
-		IdleConnTimeout:     90 * time.Second,
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS13,
-		},
-	},
-}
+var defaultHTTPClient HTTPClient = newHTTPClient(transportOptions{})
 
 // TeamsPlugin implements the Microsoft Teams notification plugin.
 type TeamsPlugin struct {
-	httpClient HTTPClient
+	httpClient  HTTPClient
+	clients     *clientCache
+	errorBuffer *errorBuffer
+
+	mentionResolver  MentionResolver
+	idempotencyCache *lruCache
+	skipLedger       *skipLedger
+	sleepFunc        func(ctx context.Context, d time.Duration) error
+	// afterFunc runs f once d has passed. Defaults to time.AfterFunc.
+	afterFunc func(d time.Duration, f func())
+	// now returns the current time. Defaults to time.Now.
+	now func() time.Time
+	// marshalFunc encodes message payloads. Defaults to marshalPayload.
+	marshalFunc func(msg TeamsMessage, pretty bool) ([]byte, error)
+
+	// Logger receives diagnostic output. Defaults to a no-op logger.
+	Logger Logger
+
+	// OnRetry, if set, is called before each retry of a send with the number
+	// of the upcoming attempt and the error that caused it.
+	OnRetry func(attempt int, err error)
+	// OnSendResult, if set, is called once per send with the number of
+	// attempts made and the final error, which is nil on success. Both
+	// callbacks may run concurrently when sending to several webhooks.
+	OnSendResult func(attempts int, err error)
+
+	// RequestInterceptor, if set, is called with every webhook request just
+	// before it is sent, e.g. to add headers. Returning an error aborts the
+	// send with that error. The request body can be read; it is restored
+	// afterwards. It may run concurrently when sending to several webhooks.
+	RequestInterceptor func(req *http.Request) error
+
+	// Tracer, if set, records OpenTelemetry spans around Execute and each
+	// webhook send. Without it no spans are created.
+	Tracer trace.Tracer
 }
 
 // Config represents the Teams plugin configuration.
 type Config struct {
+	// Enabled turns the plugin on or off without removing it from the pipeline
+	// (default: true). Setting TEAMS_DISABLED to a true value also disables it.
+	Enabled bool `json:"enabled"`
 	// WebhookURL is the Teams incoming webhook URL.
 	WebhookURL string `json:"webhook_url,omitempty"`
+	// WebhookURLs are webhooks that every notification is sent to. When
+	// WebhookURL is also set, it is appended unless already listed.
+	WebhookURLs []string `json:"webhook_urls,omitempty"`
+	// ConcurrentFanout sends to several webhooks at once instead of one after
+	// another (default: true).
+	ConcurrentFanout bool `json:"concurrent_fanout"`
+	// FanoutConcurrency bounds the webhooks sent to at once (default: 4).
+	FanoutConcurrency int `json:"fanout_concurrency"`
+	// FanoutSuccessPolicy decides when sending to several webhooks succeeds:
+	// "all" (default) requires every webhook, "any" at least one.
+	FanoutSuccessPolicy string `json:"fanout_success_policy,omitempty"`
+	// WebhookURLSuccess overrides WebhookURL for success notifications.
+	WebhookURLSuccess string `json:"webhook_url_success,omitempty"`
+	// WebhookURLError overrides WebhookURL for error notifications.
+	WebhookURLError string `json:"webhook_url_error,omitempty"`
 	// TitleTemplate is the template for the card title (default: "Release {{version}}").
 	TitleTemplate string `json:"title_template,omitempty"`
+	// TitleMaxChars cuts longer resolved titles with an ellipsis (default: 150).
+	TitleMaxChars int `json:"title_max_chars"`
+	// ResultMessageTemplate is the template for the response message after a
+	// notification is sent, e.g. "Notified #releases about {{version}}".
+	ResultMessageTemplate string `json:"result_message_template,omitempty"`
+	// StylePreset sets the defaults of the display options: "detailed",
+	// "standard" (default), "compact" or "minimal".
+	StylePreset string `json:"style_preset,omitempty"`
 	// IncludeChangelog includes changelog in the notification.
 	IncludeChangelog bool `json:"include_changelog"`
+	// RenderMarkdown renders release notes as sanitized markdown instead of
+	// escaped text (default: false).
+	RenderMarkdown bool `json:"render_markdown"`
+	// ShowSummary adds the feature, fix and breaking change counts (default: true).
+	ShowSummary bool `json:"show_summary"`
+	// ShowInfo adds the version, type, branch and tag info block (default: true).
+	ShowInfo bool `json:"show_info"`
+	// ShowActions adds the card actions, such as View Release (default: true).
+	ShowActions bool `json:"show_actions"`
+	// Labels overrides individual card labels, e.g. {"version": "Ver"}.
+	Labels map[string]string `json:"labels,omitempty"`
+	// ColorOverrides sets card colors per kind (success, error, warning,
+	// approval or breaking), taking precedence over all other color rules.
+	ColorOverrides map[string]string `json:"color_overrides,omitempty"`
+	// EnvironmentColors sets the card color per release environment
+	// (RELICTA_ENVIRONMENT), e.g. {"production": "DC3545"}.
+	EnvironmentColors map[string]string `json:"environment_colors,omitempty"`
+	// EmptyChangelogText is shown in place of the changelog when release notes are empty.
+	EmptyChangelogText string `json:"empty_changelog_text,omitempty"`
 	// ThemeColor is the accent color for the card (default: "0076D7" - Teams blue).
 	ThemeColor string `json:"theme_color,omitempty"`
+	// ValidateContrast makes Validate warn when theme_color has too little
+	// contrast against white or black text.
+	ValidateContrast bool `json:"validate_contrast"`
 	// MentionUsers is a list of user emails to @mention.
 	MentionUsers []string `json:"mention_users,omitempty"`
 	// NotifyOnSuccess sends notification on successful release.
+	// Defaults to TEAMS_NOTIFY_ON_SUCCESS when set, otherwise true.
 	NotifyOnSuccess bool `json:"notify_on_success"`
 	// NotifyOnError sends notification on failed release.
+	// Defaults to TEAMS_NOTIFY_ON_ERROR when set, otherwise true.
 	NotifyOnError bool `json:"notify_on_error"`
+	// VerifyHostIP refuses connections to private, loopback and link-local
+	// addresses. It is checked on the dialed address, after DNS resolution.
+	VerifyHostIP bool `json:"verify_host_ip"`
+	// InsecureLocalTesting accepts http and https webhooks on localhost or
+	// loopback addresses and relaxes TLS to 1.2, for testing against a local
+	// mock server. It only takes effect when TEAMS_ALLOW_INSECURE is also true.
+	InsecureLocalTesting bool `json:"insecure_local_testing"`
+	// GroupedLayout wraps the info, summary and changelog sections in a single container.
+	GroupedLayout bool `json:"grouped_layout"`
+	// Layout renders the version info as label/value "columns" or as a native
+	// "facts" FactSet, which stays aligned on narrow clients (default: "columns").
+	Layout string `json:"layout,omitempty"`
+	// WebhookURLFile is a file containing the webhook URL (e.g., a mounted secret).
+	WebhookURLFile string `json:"webhook_url_file,omitempty"`
+	// WebhookSources is the webhook URL resolution order (default: config, file, env).
+	WebhookSources []string `json:"webhook_sources,omitempty"`
+	// MentionUsersFile is a file listing user emails to @mention, one per line.
+	MentionUsersFile string `json:"mention_users_file,omitempty"`
+	// ConfigResolutionRetries is how many times to retry reading file-backed config.
+	ConfigResolutionRetries int `json:"config_resolution_retries"`
+	// LogsURLTemplate builds the "View Failed Job" link on error cards, e.g.
+	// "https://ci.example.com/{{repository}}/builds/{{commit}}".
+	LogsURLTemplate string `json:"logs_url_template,omitempty"`
+	// Idempotent skips a notification identical to one sent within
+	// IdempotencyTTLSeconds by this process.
+	Idempotent bool `json:"idempotent"`
+	// IdempotencyCacheSize bounds the number of remembered notifications (default: 1000).
+	IdempotencyCacheSize int `json:"idempotency_cache_size"`
+	// IdempotencyTTLSeconds is how long a sent notification is remembered (default: 3600).
+	IdempotencyTTLSeconds int `json:"idempotency_ttl_seconds"`
+	// SpoolDir persists messages that still fail after all retries so a later
+	// invocation can redeliver them.
+	SpoolDir string `json:"spool_dir,omitempty"`
+	// CooldownSeconds suppresses a notification to a webhook notified less
+	// than this many seconds ago (default: 0, no cooldown).
+	CooldownSeconds int `json:"cooldown_seconds"`
+	// CooldownBypassErrors sends error notifications regardless of cooldown.
+	CooldownBypassErrors bool `json:"cooldown_bypass_errors"`
+	// StateFile persists cooldown state across invocations. Without it,
+	// cooldowns only apply within one process.
+	StateFile string `json:"state_file,omitempty"`
+	// QuietUnhandled returns an empty message for hooks the plugin doesn't handle.
+	QuietUnhandled bool `json:"quiet_unhandled"`
+	// ForceStatus sends this notification type ("success" or "error") for any handled hook.
+	ForceStatus string `json:"force_status,omitempty"`
+	// PrettyPayload indents the JSON sent to webhooks, e.g. for reading raw
+	// request bodies in Logic Apps run history.
+	PrettyPayload bool `json:"pretty_payload"`
+	// SuccessStatusCodes are the HTTP statuses treated as delivered (default: 200–204).
+	SuccessStatusCodes []int `json:"success_status_codes,omitempty"`
+	// MaxRetries is the number of retries for transient send failures (default: 3).
+	MaxRetries int `json:"max_retries"`
+	// RetryBackoffMS is the base delay between retries in milliseconds.
+	RetryBackoffMS int `json:"retry_backoff_ms"`
+	// RetryStrategy is "exponential" (default, with jitter) or "fixed".
+	RetryStrategy string `json:"retry_strategy,omitempty"`
+	// MinSeverity suppresses notifications below this severity ("info", "warning" or "error").
+	MinSeverity string `json:"min_severity,omitempty"`
+	// NotifyOnApproval sends a notification when the release is approved (PostApprove hook).
+	NotifyOnApproval bool `json:"notify_on_approval"`
+	// RequestTimeoutSeconds bounds each send attempt; retries get a fresh
+	// timeout (default: 10).
+	RequestTimeoutSeconds int `json:"request_timeout_seconds"`
+	// ConnectTimeoutMS bounds DNS resolution and connection setup in milliseconds
+	// (0 leaves it to the overall request timeout).
+	ConnectTimeoutMS int `json:"connect_timeout_ms"`
+	// ReadTimeoutMS bounds the wait for a response once the request is sent, in
+	// milliseconds (0 leaves it to the overall request timeout).
+	ReadTimeoutMS int `json:"read_timeout_ms"`
+	// PinnedCertSHA256 is the hex SHA-256 fingerprint a server certificate must match.
+	// Pins must be updated whenever Microsoft rotates the pinned certificate.
+	PinnedCertSHA256 string `json:"pinned_cert_sha256,omitempty"`
+	// CACertFile is a PEM bundle of extra trusted root certificates, for
+	// TLS-inspecting proxies that present a corporate CA.
+	CACertFile string `json:"ca_cert_file,omitempty"`
+	// ProxyURL routes sends through an http or https proxy. When unset, the
+	// HTTPS_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string `json:"proxy_url,omitempty"`
+	// Importance renders a "high" or "urgent" banner at the top of the card
+	// (default: "normal", no banner).
+	Importance string `json:"importance,omitempty"`
+	// ExpireAfterSeconds adds an "Expires" note to the card, this many seconds
+	// after sending (0 disables it). Webhooks can't delete messages, so the
+	// card stays in the channel.
+	ExpireAfterSeconds int `json:"expire_after_seconds"`
+	// SuccessIcon is a Unicode/emoji icon prefixed to the success card header.
+	SuccessIcon string `json:"success_icon,omitempty"`
+	// ErrorIcon is a Unicode/emoji icon prefixed to the error card header.
+	ErrorIcon string `json:"error_icon,omitempty"`
+	// RedactErrorPatterns are regular expressions whose matches are replaced
+	// with "***" in error card text, keeping secrets out of the channel.
+	RedactErrorPatterns []string `json:"redact_error_patterns,omitempty"`
+	// CoalesceErrors sends the first error notification of a release at once
+	// and summarizes later ones within the process: see
+	// sendCoalescedErrorNotification for when buffered failures are sent.
+	CoalesceErrors bool `json:"coalesce_errors"`
+	// CoalesceWindowSeconds is how long failures after the first are buffered
+	// (default: 60).
+	CoalesceWindowSeconds int `json:"coalesce_window_seconds"`
+	// CoalesceMaxErrors flushes the buffer once this many failures wait (default: 5).
+	CoalesceMaxErrors int `json:"coalesce_max_errors"`
+	// BackgroundImageURL is an HTTPS image rendered behind the card body.
+	BackgroundImageURL string `json:"background_image_url,omitempty"`
+	// Language sets the card's lang attribute for date and number formatting.
+	// Teams renders cards as "en" when unset.
+	Language string `json:"language,omitempty"`
+	// RTL renders cards right-to-left, e.g. for Arabic or Hebrew teams.
+	RTL bool `json:"rtl"`
+	// FooterTimestamp adds a "Sent" footer that Teams localizes for each viewer.
+	FooterTimestamp bool `json:"footer_timestamp"`
+	// Timezone is the IANA timezone of the footer timestamp (default: UTC).
+	Timezone string `json:"timezone,omitempty"`
+	// Components adds a carousel card per release component to success notifications.
+	Components []Component `json:"components,omitempty"`
+	// Stage is the current pipeline stage, rendered as a stepper over Stages.
+	Stage string `json:"stage,omitempty"`
+	// Stages lists the pipeline stages in order (default: init, build, publish, done).
+	Stages []string `json:"stages,omitempty"`
+	// MaxActions caps the number of card actions; actions are kept in priority
+	// order and extras are summarized. Zero disables the cap (default: 6).
+	MaxActions int `json:"max_actions"`
+	// ActionsPosition places actions at the "bottom" of the card (default) or
+	// as an inline ActionSet at the "top" of the body.
+	ActionsPosition string `json:"actions_position,omitempty"`
+	// ShowDeprecations renders deprecation commits in an amber section (default: true).
+	ShowDeprecations bool `json:"show_deprecations"`
+	// ShowCardDetails moves the changes summary and changelog behind a "Show details" action.
+	ShowCardDetails bool `json:"show_card_details"`
+	// ShowQRCode adds a QR code image linking to the release page.
+	ShowQRCode bool `json:"show_qr_code"`
+	// UpgradeInstructions is a markdown template rendered under a "How to
+	// upgrade" heading, e.g. "Run `npm install acme@{{version}}`".
+	UpgradeInstructions string `json:"upgrade_instructions,omitempty"`
+	// ExtraFacts adds custom label/value facts to the success card, sorted by label.
+	ExtraFacts map[string]string `json:"extra_facts,omitempty"`
+	// MaxExtraFacts caps the extra facts shown; the rest are summarized.
+	// Zero disables the cap (default: 15).
+	MaxExtraFacts int `json:"max_extra_facts"`
+	// GroupByScope lists changes under their conventional commit scope
+	// (api, ui, ...), falling back to categories when no commit has a scope.
+	GroupByScope bool `json:"group_by_scope"`
+	// IncludeChangeDetails lists breaking change, feature and fix descriptions
+	// under category headings. group_by_scope takes precedence.
+	IncludeChangeDetails bool `json:"include_change_details"`
+	// ChangeDetailsLimit caps the changes listed per category by
+	// include_change_details (default: 5).
+	ChangeDetailsLimit int `json:"change_details_limit"`
+	// CategoryEmoji prefixes change category headings with an emoji, e.g.
+	// "✨ Features" (default: true with the detailed style preset).
+	CategoryEmoji bool `json:"category_emoji"`
+	// LinkifyIssues links #N references in change descriptions to the
+	// repository's issues (and !N to GitLab merge requests).
+	LinkifyIssues bool `json:"linkify_issues"`
+	// ShowContributorCount adds a fact with the number of unique commit authors.
+	ShowContributorCount bool `json:"show_contributor_count"`
+	// ShowPreviousVersion adds a fact with the version upgraded from, linked
+	// to the compare view, or "Initial release" for a first release.
+	ShowPreviousVersion bool `json:"show_previous_version"`
+	// ShowCIProvider adds a fact naming the CI provider and event that
+	// triggered the release.
+	ShowCIProvider bool `json:"show_ci_provider"`
+	// CIProvider overrides the CI provider detected from the environment.
+	CIProvider string `json:"ci_provider,omitempty"`
+	// EmbedMetadata adds a hidden JSON release summary for bots that read the channel.
+	EmbedMetadata bool `json:"embed_metadata"`
+	// ReleaseTypeBadge renders the release type as a colored pill instead of plain text.
+	ReleaseTypeBadge bool `json:"release_type_badge"`
+	// DecoratePrerelease labels prerelease titles, e.g. "Release 1.2.3-rc.1 (Release Candidate)".
+	DecoratePrerelease bool `json:"decorate_prerelease"`
+	// SkipEmptyRelease suppresses success notifications for releases without changes or notes.
+	SkipEmptyRelease bool `json:"skip_empty_release"`
+	// SkipMarker suppresses notifications for releases whose notes or title
+	// contain it, ignoring case (default: "[skip-teams]").
+	SkipMarker string `json:"skip_marker,omitempty"`
+	// ResolveMentions looks mention users up in Microsoft Graph so mentions use
+	// Azure AD object IDs, falling back to email mentions when a lookup fails.
+	ResolveMentions bool `json:"resolve_mentions"`
+	// GraphTenantID is the Azure AD tenant of the app used for mention lookups.
+	GraphTenantID string `json:"graph_tenant_id,omitempty"`
+	// GraphClientID is the application (client) ID used for mention lookups.
+	GraphClientID string `json:"graph_client_id,omitempty"`
+	// GraphClientSecret is the client secret used for mention lookups.
+	GraphClientSecret string `json:"graph_client_secret,omitempty"`
+	// MaxMentions caps the mentions per card (0 means no cap).
+	MaxMentions int `json:"max_mentions"`
+	// ChunkMentions splits mentions over the cap across several cards instead of dropping them.
+	ChunkMentions bool `json:"chunk_mentions"`
+	// MentionOnlyOnBreaking drops mentions from success notifications for
+	// releases without breaking changes. Other notifications still mention.
+	MentionOnlyOnBreaking bool `json:"mention_only_on_breaking"`
+	// ShowApprover adds an "Approved by" fact when approval data is available.
+	ShowApprover bool `json:"show_approver"`
+	// ApprovedBy is the approver; overrides RELICTA_APPROVED_BY from the release environment.
+	ApprovedBy string `json:"approved_by,omitempty"`
+	// ApprovedAt is the approval time; overrides RELICTA_APPROVED_AT from the release environment.
+	ApprovedAt string `json:"approved_at,omitempty"`
+	// RelativeTime renders "Released 2 minutes ago" under the success card title.
+	RelativeTime bool `json:"relative_time"`
+	// ReleasedAt is the RFC 3339 release time; overrides RELICTA_RELEASED_AT from the release environment.
+	ReleasedAt string `json:"released_at,omitempty"`
+	// DigestWebhookURL receives a one-line summary of each notification.
+	DigestWebhookURL string `json:"digest_webhook_url,omitempty"`
+	// ReportSkips counts skipped notifications and posts a summary of them
+	// from the OnSuccess and OnError hooks.
+	ReportSkips bool `json:"report_skips"`
+	// SkipReportWebhookURL receives the skip summary (defaults to webhook_url).
+	SkipReportWebhookURL string `json:"skip_report_webhook_url,omitempty"`
+	// StripANSI removes ANSI escape sequences from release notes (default: true).
+	StripANSI bool `json:"strip_ansi"`
+	// ChangelogMaxLength truncates longer release notes, in characters (default: 2000).
+	ChangelogMaxLength int `json:"changelog_max_length"`
+	// ReleaseNotesURL is linked when the changelog is truncated (default: the release page).
+	ReleaseNotesURL string `json:"release_notes_url,omitempty"`
+	// AllowedActionHosts restricts the hosts of configured action URLs
+	// (logs_url_template, release_notes_url) to these host suffixes.
+	// Empty allows any HTTPS host.
+	AllowedActionHosts []string `json:"allowed_action_hosts,omitempty"`
 }
 
 // TeamsMessage represents a Microsoft Teams message payload with Adaptive Card.
 type TeamsMessage struct {
 	Type        string            `json:"type"`
 	Attachments []TeamsAttachment `json:"attachments"`
+	// AttachmentLayout is "carousel" when several cards are attached.
+	AttachmentLayout string `json:"attachmentLayout,omitempty"`
 }
 
 // TeamsAttachment represents an attachment in a Teams message.
@@ -93,28 +375,41 @@ type TeamsAttachment struct {
 
 // AdaptiveCard represents a Microsoft Adaptive Card.
 type AdaptiveCard struct {
-	Type    string            `json:"type"`
-	Version string            `json:"version"`
-	Schema  string            `json:"$schema"`
-	Body    []AdaptiveElement `json:"body"`
-	Actions []AdaptiveAction  `json:"actions,omitempty"`
-	MSTeams *MSTeamsConfig    `json:"msteams,omitempty"`
+	Type            string            `json:"type"`
+	Version         string            `json:"version"`
+	Schema          string            `json:"$schema"`
+	Lang            string            `json:"lang,omitempty"`
+	RTL             bool              `json:"rtl,omitempty"`
+	BackgroundImage string            `json:"backgroundImage,omitempty"`
+	Body            []AdaptiveElement `json:"body"`
+	Actions         []AdaptiveAction  `json:"actions,omitempty"`
+	MSTeams         *MSTeamsConfig    `json:"msteams,omitempty"`
 }
 
 // AdaptiveElement represents an element in an Adaptive Card body.
 type AdaptiveElement struct {
-	Type      string            `json:"type"`
-	Text      string            `json:"text,omitempty"`
-	Weight    string            `json:"weight,omitempty"`
-	Size      string            `json:"size,omitempty"`
-	Wrap      bool              `json:"wrap,omitempty"`
-	Color     string            `json:"color,omitempty"`
-	Style     string            `json:"style,omitempty"`
-	Bleed     bool              `json:"bleed,omitempty"`
-	Separator bool              `json:"separator,omitempty"`
-	Spacing   string            `json:"spacing,omitempty"`
-	Items     []AdaptiveElement `json:"items,omitempty"`
-	Columns   []ColumnDefinition`json:"columns,omitempty"`
+	Type       string             `json:"type"`
+	ID         string             `json:"id,omitempty"`
+	IsVisible  *bool              `json:"isVisible,omitempty"`
+	Text       string             `json:"text,omitempty"`
+	Weight     string             `json:"weight,omitempty"`
+	Size       string             `json:"size,omitempty"`
+	Wrap       bool               `json:"wrap,omitempty"`
+	Color      string             `json:"color,omitempty"`
+	IsSubtle   bool               `json:"isSubtle,omitempty"`
+	Style      string             `json:"style,omitempty"`
+	Bleed      bool               `json:"bleed,omitempty"`
+	Separator  bool               `json:"separator,omitempty"`
+	Spacing    string             `json:"spacing,omitempty"`
+	ShowBorder bool               `json:"showBorder,omitempty"`
+	Items      []AdaptiveElement  `json:"items,omitempty"`
+	Columns    []ColumnDefinition `json:"columns,omitempty"`
+	Facts      []Fact             `json:"facts,omitempty"`
+	Actions    []AdaptiveAction   `json:"actions,omitempty"`
+	// URL and AltText are set on Image elements.
+	URL                 string `json:"url,omitempty"`
+	AltText             string `json:"altText,omitempty"`
+	HorizontalAlignment string `json:"horizontalAlignment,omitempty"`
 }
 
 // ColumnDefinition represents a column in a ColumnSet.
@@ -124,11 +419,18 @@ type ColumnDefinition struct {
 	Items []AdaptiveElement `json:"items"`
 }
 
+// Fact represents a label/value pair in a FactSet.
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
 // AdaptiveAction represents an action in an Adaptive Card.
 type AdaptiveAction struct {
-	Type  string `json:"type"`
-	Title string `json:"title"`
-	URL   string `json:"url,omitempty"`
+	Type  string        `json:"type"`
+	Title string        `json:"title"`
+	URL   string        `json:"url,omitempty"`
+	Card  *AdaptiveCard `json:"card,omitempty"`
 }
 
 // MSTeamsConfig represents Teams-specific configuration.
@@ -156,6 +458,102 @@ const (
 	DefaultThemeColor    = "0076D7" // Teams blue
 	ColorSuccess         = "28A745" // Green
 	ColorError           = "DC3545" // Red
+	ColorApproval        = "17A2B8" // Info blue
+)
+
+// languageTagPattern loosely matches BCP 47 language tags such as "en" or "pt-BR".
+var languageTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// rtlLanguages are the primary language subtags of common right-to-left scripts.
+var rtlLanguages = map[string]bool{"ar": true, "fa": true, "he": true, "ps": true, "ur": true, "yi": true}
+
+// isRTLLanguage reports whether a language tag such as "ar-EG" is usually
+// written right-to-left.
+func isRTLLanguage(tag string) bool {
+	primary, _, _ := strings.Cut(tag, "-")
+	return rtlLanguages[strings.ToLower(primary)]
+}
+
+// ansiEscapePattern matches ANSI escape sequences: CSI sequences such as
+// colors ("\x1b[31m"), OSC sequences such as hyperlinks, and two-byte escapes.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// stripANSI removes ANSI escape sequences from s.
+func stripANSI(s string) string {
+	return ansiEscapePattern.ReplaceAllString(s, "")
+}
+
+// EnvLogsURL carries the failed job's logs URL in the release environment.
+const EnvLogsURL = "RELICTA_LOGS_URL"
+
+// EnvDisabled mutes the plugin when set to a true value.
+const EnvDisabled = "TEAMS_DISABLED"
+
+// Environment variables overriding the notify_on_success and notify_on_error
+// defaults, so each environment can choose what it notifies on without its
+// own config file. Explicit config values still win.
+const (
+	EnvNotifyOnSuccess = "TEAMS_NOTIFY_ON_SUCCESS"
+	EnvNotifyOnError   = "TEAMS_NOTIFY_ON_ERROR"
+)
+
+// envBool parses the environment variable key as a bool, returning
+// defaultVal when it is unset or not a valid bool.
+func envBool(key string, defaultVal bool) bool {
+	v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(key)))
+	if err != nil {
+		return defaultVal
+	}
+	return v
+}
+
+// DefaultTitleMaxChars is the default maximum length of a resolved title, in characters.
+const DefaultTitleMaxChars = 150
+
+// Changelog truncation default and upper bound, in characters. Workflow webhooks
+// accept larger cards than connectors, hence the headroom.
+const (
+	DefaultChangelogMaxLength = 2000
+	MaxChangelogMaxLength     = 20000
+)
+
+// DefaultSkipMarker suppresses a release's notifications when found in its notes or title.
+const DefaultSkipMarker = "[skip-teams]"
+
+// DefaultMaxActions is the default cap on actions per card, matching Teams'
+// practical limit.
+const DefaultMaxActions = 6
+
+// Action positions accepted by actions_position.
+const (
+	ActionsPositionBottom = "bottom"
+	ActionsPositionTop    = "top"
+)
+
+// MaxIconLength is the maximum length, in characters, of success_icon and error_icon.
+const MaxIconLength = 16
+
+// AdaptiveCardVersion is the Adaptive Card schema version of every card sent.
+const AdaptiveCardVersion = "1.2"
+
+// Version info layouts accepted by layout.
+const (
+	LayoutColumns = "columns"
+	LayoutFacts   = "facts"
+)
+
+// Message importance levels accepted by importance.
+const (
+	ImportanceNormal = "normal"
+	ImportanceHigh   = "high"
+	ImportanceUrgent = "urgent"
+)
+
+// Notification statuses, also accepted by force_status.
+const (
+	StatusSuccess  = "success"
+	StatusError    = "error"
+	StatusApproval = "approval"
 )
 
 // GetInfo returns plugin metadata.
@@ -166,6 +564,7 @@ func (p *TeamsPlugin) GetInfo() plugin.Info {
 		Description: "Send release notifications to Microsoft Teams",
 		Author:      "Relicta Team",
 		Hooks: []plugin.Hook{
+			plugin.HookPostApprove,
 			plugin.HookPostPublish,
 			plugin.HookOnSuccess,
 			plugin.HookOnError,
@@ -173,15 +572,123 @@ func (p *TeamsPlugin) GetInfo() plugin.Info {
 		ConfigSchema: `{
 			"type": "object",
 			"properties": {
+				"enabled": {"type": "boolean", "description": "Send notifications (or set TEAMS_DISABLED=true to mute)", "default": true},
 				"webhook_url": {"type": "string", "description": "Teams incoming webhook URL (or use TEAMS_WEBHOOK_URL env)"},
-				"title_template": {"type": "string", "description": "Template for card title", "default": "Release {{version}}"},
+				"webhook_sources": {"type": "array", "items": {"type": "string", "enum": ["config", "file", "env"]}, "description": "Webhook URL resolution order", "default": ["config", "file", "env"]},
+				"webhook_urls": {"type": "array", "items": {"type": "string"}, "description": "Webhooks that every notification is sent to; webhook_url, if also set, is appended unless already listed"},
+				"concurrent_fanout": {"type": "boolean", "description": "Send to several webhooks concurrently", "default": true},
+				"fanout_concurrency": {"type": "integer", "description": "Maximum webhooks sent to at once", "default": 4, "minimum": 1},
+				"fanout_success_policy": {"type": "string", "enum": ["all", "any"], "description": "Whether sending to several webhooks succeeds when all of them or any of them succeed", "default": "all"},
+				"webhook_url_success": {"type": "string", "description": "Webhook for success notifications (defaults to webhook_url)"},
+				"webhook_url_error": {"type": "string", "description": "Webhook for error notifications (defaults to webhook_url)"},
+				"webhook_url_file": {"type": "string", "description": "File containing the webhook URL, used when webhook_url is not set"},
+				"pretty_payload": {"type": "boolean", "description": "Indent the JSON payload, e.g. for reading request bodies in Logic Apps run history", "default": false},
+				"success_status_codes": {"type": "array", "items": {"type": "integer", "minimum": 200, "maximum": 299}, "description": "HTTP statuses treated as delivered (default: 200-204)"},
+				"max_retries": {"type": "integer", "description": "Retries for network errors, 5xx responses and rate limiting (429, honoring Retry-After)", "default": 3, "minimum": 0, "maximum": 10},
+				"retry_backoff_ms": {"type": "integer", "description": "Base delay between retries in milliseconds", "default": 500, "minimum": 0, "maximum": 60000},
+				"retry_strategy": {"type": "string", "enum": ["exponential", "fixed"], "description": "Backoff between retries: exponential with jitter, or a fixed interval", "default": "exponential"},
+				"request_timeout_seconds": {"type": "integer", "description": "Timeout for each send attempt in seconds; retries get a fresh timeout", "default": 10, "minimum": 1, "maximum": 120},
+				"connect_timeout_ms": {"type": "integer", "description": "Connection setup timeout in milliseconds (0 uses the overall request timeout)", "default": 0, "minimum": 0, "maximum": 120000},
+				"read_timeout_ms": {"type": "integer", "description": "Response wait timeout in milliseconds (0 uses the overall request timeout)", "default": 0, "minimum": 0, "maximum": 120000},
+				"pinned_cert_sha256": {"type": "string", "description": "Hex SHA-256 fingerprint of a certificate in the server chain; must be updated when Microsoft rotates certificates"},
+				"ca_cert_file": {"type": "string", "description": "PEM bundle of additional trusted root certificates, e.g. a corporate CA for TLS-inspecting proxies"},
+				"proxy_url": {"type": "string", "description": "HTTP or HTTPS proxy for sends, e.g. http://proxy.corp:3128 (defaults to the HTTPS_PROXY environment variable, honoring NO_PROXY)"},
+				"importance": {"type": "string", "enum": ["normal", "high", "urgent"], "description": "Message importance; high and urgent render a banner at the top of the card", "default": "normal"},
+				"expire_after_seconds": {"type": "integer", "description": "Seconds after sending at which the card shows itself as expired, via an Expires note (0 disables it); webhooks can't delete messages", "default": 0, "minimum": 0},
+				"success_icon": {"type": "string", "description": "Unicode/emoji icon shown before the success card title", "maxLength": 16},
+				"error_icon": {"type": "string", "description": "Unicode/emoji icon shown before the error card title", "maxLength": 16},
+				"redact_error_patterns": {"type": "array", "items": {"type": "string"}, "description": "Regular expressions whose matches are replaced with *** in error card text"},
+				"coalesce_errors": {"type": "boolean", "description": "Send the first error notification of a release at once and one summary card for later failures, when the window ends, a success hook runs, one is marked final by RELICTA_FINAL_ERROR or coalesce_max_errors are buffered", "default": false},
+				"coalesce_window_seconds": {"type": "integer", "description": "Seconds after the first failure during which later failures are buffered", "default": 60, "minimum": 1},
+				"coalesce_max_errors": {"type": "integer", "description": "Buffered failures that trigger a summary card", "default": 5, "minimum": 1, "maximum": 100},
+				"env_undefined": {"type": "string", "enum": ["empty", "literal"], "description": "How ${VAR} references to undefined environment variables are expanded", "default": "empty"},
+				"background_image_url": {"type": "string", "description": "HTTPS URL of an image rendered behind the card"},
+				"language": {"type": "string", "description": "Card language (BCP 47 tag, e.g. 'de-DE') for date and number formatting; Teams uses 'en' when unset"},
+				"rtl": {"type": "boolean", "description": "Render cards right-to-left, e.g. together with language 'ar' or 'he'", "default": false},
+				"footer_timestamp": {"type": "boolean", "description": "Add a send time footer localized by Teams for each viewer", "default": false},
+				"timezone": {"type": "string", "description": "IANA timezone of the footer timestamp (e.g., 'Europe/Berlin')", "default": "UTC"},
+				"components": {"type": "array", "description": "Release components, each rendered as a carousel card", "items": {"type": "object", "properties": {"name": {"type": "string"}, "changes": {"type": "array", "items": {"type": "string"}}}, "required": ["name"]}},
+				"max_actions": {"type": "integer", "description": "Maximum actions per card; extras are dropped and summarized (0 disables the cap)", "default": 6, "minimum": 0},
+				"actions_position": {"type": "string", "enum": ["bottom", "top"], "description": "Where card actions render: the card-level actions at the bottom, or an inline ActionSet at the top of the body", "default": "bottom"},
+				"show_deprecations": {"type": "boolean", "description": "Show deprecation commits in a highlighted section", "default": true},
+				"show_card_details": {"type": "boolean", "description": "Move changes and changelog behind an expandable Show details action", "default": false},
+				"show_qr_code": {"type": "boolean", "description": "Add a QR code linking to the release page, generated in-process", "default": false},
+				"upgrade_instructions": {"type": "string", "description": "Markdown shown under a 'How to upgrade' heading on success cards, with the title_template fields such as {{version}}"},
+				"stage": {"type": "string", "description": "Current pipeline stage, highlighted in a progress stepper"},
+				"stages": {"type": "array", "items": {"type": "string"}, "description": "Pipeline stages in order for the progress stepper", "default": ["init", "build", "publish", "done"]},
+				"extra_facts": {"type": "object", "description": "Custom facts shown on success cards, keyed by label", "additionalProperties": {"type": "string"}},
+				"max_extra_facts": {"type": "integer", "description": "Maximum extra facts shown; the rest are summarized (0 means no cap)", "default": 15, "minimum": 0},
+				"group_by_scope": {"type": "boolean", "description": "List changes grouped by commit scope (falls back to categories)", "default": false},
+				"include_change_details": {"type": "boolean", "description": "List breaking change, feature and fix descriptions under category headings (at most 30 per card); group_by_scope takes precedence", "default": false},
+				"change_details_limit": {"type": "integer", "description": "Changes listed per category by include_change_details; the rest are summarized as \"…and N more\"", "default": 5, "minimum": 1, "maximum": 50},
+				"linkify_issues": {"type": "boolean", "description": "Link #123 references in change descriptions to the repository's issues or pull requests (GitHub and GitLab)", "default": false},
+				"category_emoji": {"type": "boolean", "description": "Prefix change category headings with an emoji, e.g. ✨ Features (defaults to true with the detailed style preset)", "default": false},
+				"show_contributor_count": {"type": "boolean", "description": "Show the number of unique commit authors", "default": false},
+				"show_previous_version": {"type": "boolean", "description": "Show the version upgraded from, linked to the compare view", "default": false},
+				"show_ci_provider": {"type": "boolean", "description": "Show the CI provider and event that triggered the release", "default": false},
+				"ci_provider": {"type": "string", "description": "CI provider shown by show_ci_provider (detected from GITHUB_ACTIONS, GITLAB_CI and similar when unset)"},
+				"embed_metadata": {"type": "boolean", "description": "Embed a hidden machine-readable release summary in the card", "default": false},
+				"release_type_badge": {"type": "boolean", "description": "Render the release type as a colored badge", "default": false},
+				"decorate_prerelease": {"type": "boolean", "description": "Append a label such as (Release Candidate) to the title of prerelease versions", "default": false},
+				"skip_empty_release": {"type": "boolean", "description": "Skip success notifications for releases with no changes and no release notes", "default": false},
+				"skip_marker": {"type": "string", "description": "Skip notifications for releases whose notes or title contain this marker (case-insensitive)", "default": "[skip-teams]"},
+				"resolve_mentions": {"type": "boolean", "description": "Resolve mention users to Azure AD IDs via Microsoft Graph", "default": false},
+				"graph_tenant_id": {"type": "string", "description": "Azure AD tenant ID for mention lookups (or use TEAMS_GRAPH_TENANT_ID env)"},
+				"graph_client_id": {"type": "string", "description": "Application ID for mention lookups (or use TEAMS_GRAPH_CLIENT_ID env)"},
+				"graph_client_secret": {"type": "string", "description": "Client secret for mention lookups (or use TEAMS_GRAPH_CLIENT_SECRET env)"},
+				"max_mentions": {"type": "integer", "description": "Maximum mentions per card; extras are dropped unless chunk_mentions is set (0 means no cap)", "default": 0, "minimum": 0},
+				"chunk_mentions": {"type": "boolean", "description": "Send several cards so every mention over max_mentions is delivered", "default": false},
+				"mention_only_on_breaking": {"type": "boolean", "description": "Only mention users on success notifications for releases with breaking changes; error notifications always mention", "default": false},
+				"min_severity": {"type": "string", "enum": ["info", "warning", "error"], "description": "Only notify at or above this severity (success is info, approval is warning, failure is error)", "default": "info"},
+				"notify_on_approval": {"type": "boolean", "description": "Notify when the release is approved", "default": false},
+				"show_approver": {"type": "boolean", "description": "Show who approved the release in the success card", "default": false},
+				"approved_by": {"type": "string", "description": "Approver name (defaults to RELICTA_APPROVED_BY from the release environment)"},
+				"approved_at": {"type": "string", "description": "Approval time (defaults to RELICTA_APPROVED_AT from the release environment)"},
+				"relative_time": {"type": "boolean", "description": "Show how long ago the release happened, e.g. 'Released 2 minutes ago'", "default": false},
+				"released_at": {"type": "string", "description": "RFC 3339 release time (defaults to RELICTA_RELEASED_AT from the release environment)"},
+				"digest_webhook_url": {"type": "string", "description": "Secondary webhook that receives a one-line summary of each notification"},
+				"report_skips": {"type": "boolean", "description": "Count skipped notifications and post a summary from the on-success and on-error hooks", "default": false},
+				"skip_report_webhook_url": {"type": "string", "description": "Admin webhook that receives the skip summary (defaults to webhook_url)"},
+				"title_template": {"type": "string", "description": "Template for card title: {{version}}, {{previous_version}}, {{tag}}, {{branch}}, {{commit}}, {{release_type}}, {{repository}} and {{repository_url}}, or Go template syntax such as {{ .Version | upper }} with upper, lower, truncate, default and trimPrefix", "default": "Release {{version}}"},
+				"title_max_chars": {"type": "integer", "description": "Maximum length of the resolved title; longer titles are cut with an ellipsis", "default": 150, "minimum": 1},
+				"result_message_template": {"type": "string", "description": "Template for the response message after sending, with the title_template fields plus .Status and .Webhooks (defaults to \"Sent Teams <status> notification\")"},
+				"style_preset": {"type": "string", "enum": ["detailed", "standard", "compact", "minimal"], "description": "Defaults for the display options; options set explicitly still override the preset", "default": "standard"},
 				"include_changelog": {"type": "boolean", "description": "Include changelog in message", "default": true},
+				"render_markdown": {"type": "boolean", "description": "Render release notes and upgrade instructions as markdown (bullets, bold, links); HTML, images and non-web links are stripped", "default": false},
+				"show_summary": {"type": "boolean", "description": "Show the feature, fix and breaking change counts", "default": true},
+				"show_info": {"type": "boolean", "description": "Show the version, type, branch and tag info block", "default": true},
+				"show_actions": {"type": "boolean", "description": "Show card actions such as View Release", "default": true},
+				"strip_ansi": {"type": "boolean", "description": "Remove ANSI escape sequences from release notes", "default": true},
+				"changelog_max_length": {"type": "integer", "description": "Characters at which release notes are truncated, before escaping", "default": 2000, "minimum": 1, "maximum": 20000},
+				"release_notes_url": {"type": "string", "description": "Link shown when the changelog is truncated (defaults to the release page)"},
+				"allowed_action_hosts": {"type": "array", "items": {"type": "string"}, "description": "Host suffixes that configured action URLs must match, e.g. [\"github.com\"] (empty allows any HTTPS host)"},
+				"labels": {"type": "object", "description": "Override card labels (version, type, branch, tag, approved_by, changes, contributors, ci_provider, previous_version)", "additionalProperties": {"type": "string"}},
+				"color_overrides": {"type": "object", "description": "Card color per kind (success, error, warning, approval, breaking), hex without #", "additionalProperties": {"type": "string"}},
+				"environment_colors": {"type": "object", "description": "Card color per release environment (RELICTA_ENVIRONMENT), hex without #", "additionalProperties": {"type": "string"}},
+				"empty_changelog_text": {"type": "string", "description": "Placeholder shown when include_changelog is on but the release has no notes (e.g. 'No release notes provided')"},
 				"theme_color": {"type": "string", "description": "Accent color for the card (hex without #)", "default": "0076D7"},
+				"validate_contrast": {"type": "boolean", "description": "Warn during validation when theme_color has a WCAG contrast ratio below 3:1 against white or black text", "default": false},
 				"mention_users": {"type": "array", "items": {"type": "string"}, "description": "User emails to @mention"},
-				"notify_on_success": {"type": "boolean", "description": "Notify on success", "default": true},
-				"notify_on_error": {"type": "boolean", "description": "Notify on error", "default": true}
+				"mention_users_file": {"type": "string", "description": "File listing user emails to @mention, one per line"},
+				"config_resolution_retries": {"type": "integer", "description": "Retries for reading file-backed config", "default": 0, "minimum": 0, "maximum": 10},
+				"notify_on_success": {"type": "boolean", "description": "Notify on success (default from TEAMS_NOTIFY_ON_SUCCESS env, else true)", "default": true},
+				"notify_on_error": {"type": "boolean", "description": "Notify on error (default from TEAMS_NOTIFY_ON_ERROR env, else true)", "default": true},
+				"verify_host_ip": {"type": "boolean", "description": "Refuse connections to private, loopback or link-local addresses; checked on the dialed address, so it has no effect through proxy_url", "default": false},
+				"insecure_local_testing": {"type": "boolean", "description": "Allow http and https webhooks on localhost for testing against a local mock server; requires TEAMS_ALLOW_INSECURE=true as well", "default": false},
+				"logs_url_template": {"type": "string", "description": "Failed job logs URL for error cards, with the title_template fields such as {{repository}} and {{commit}} (falls back to RELICTA_LOGS_URL)"},
+				"idempotent": {"type": "boolean", "description": "Skip notifications identical to one recently sent by this process", "default": false},
+				"idempotency_cache_size": {"type": "integer", "description": "Maximum remembered notifications for idempotent", "default": 1000, "minimum": 1},
+				"idempotency_ttl_seconds": {"type": "integer", "description": "How long sent notifications are remembered for idempotent", "default": 3600, "minimum": 1},
+				"spool_dir": {"type": "string", "description": "Directory persisting messages that fail after all retries; they are redelivered on the next invocation. The directory is restricted to the owner"},
+				"cooldown_seconds": {"type": "integer", "description": "Suppress notifications to a webhook notified less than this many seconds ago (0 disables)", "default": 0, "minimum": 0},
+				"cooldown_bypass_errors": {"type": "boolean", "description": "Send error notifications regardless of cooldown_seconds", "default": false},
+				"state_file": {"type": "string", "description": "File persisting cooldown state across invocations"},
+				"quiet_unhandled": {"type": "boolean", "description": "Return an empty message for unhandled hooks", "default": false},
+				"force_status": {"type": "string", "enum": ["", "success", "error"], "description": "Send this notification type for any handled hook instead of routing by hook", "default": ""},
+				"grouped_layout": {"type": "boolean", "description": "Group card sections into a single bordered container", "default": false},
+				"layout": {"type": "string", "enum": ["columns", "facts"], "description": "Version info as label/value columns or as a FactSet, which stays aligned on narrow mobile clients", "default": "columns"}
 			},
-			"required": ["webhook_url"]
+			"anyOf": [{"required": ["webhook_url"]}, {"required": ["webhook_url_file"]}, {"required": ["webhook_urls"]}]
 		}`,
 	}
 }
@@ -189,37 +696,190 @@ func (p *TeamsPlugin) GetInfo() plugin.Info {
 // Execute runs the plugin for a given hook.
 func (p *TeamsPlugin) Execute(ctx context.Context, req plugin.ExecuteRequest) (*plugin.ExecuteResponse, error) {
 	cfg := p.parseConfig(req.Config)
+	p.getLogger().Debug("config parsed",
+		"hook", string(req.Hook),
+		"webhook", redactWebhookURL(cfg.WebhookURL),
+		"dry_run", req.DryRun)
+
+	ctx, span := p.startSpan(ctx, spanExecute,
+		attrHook.String(string(req.Hook)),
+		attrDryRun.Bool(req.DryRun),
+		attrWebhook.String(redactWebhookURL(cfg.WebhookURL)))
+	defer span.End()
+
+	resp, err := p.execute(ctx, cfg, req)
+	endExecuteSpan(span, resp, err)
+	return resp, err
+}
+
+// execute handles a hook with parsed config.
+func (p *TeamsPlugin) execute(ctx context.Context, cfg *Config, req plugin.ExecuteRequest) (*plugin.ExecuteResponse, error) {
+	// The kill switch mutes every hook, e.g. during incidents
+	if !cfg.Enabled {
+		return p.skip(cfg, SkipDisabled, "Teams plugin disabled"), nil
+	}
+
+	if cfg.InsecureLocalTesting {
+		p.getLogger().Warn("insecure_local_testing is enabled; security hardening is relaxed for localhost webhooks")
+	}
 
+	resp, err := p.executeHook(ctx, cfg, req)
+	if err == nil && cfg.ReportSkips && isSkipFlushHook(req.Hook) {
+		p.reportSkips(ctx, cfg, resp, req.DryRun)
+	}
+	return resp, err
+}
+
+// executeHook sends the notification for a hook of an enabled plugin.
+func (p *TeamsPlugin) executeHook(ctx context.Context, cfg *Config, req plugin.ExecuteRequest) (*plugin.ExecuteResponse, error) {
+	var status string
 	switch req.Hook {
 	case plugin.HookPostPublish, plugin.HookOnSuccess:
-		if !cfg.NotifyOnSuccess {
-			return &plugin.ExecuteResponse{
-				Success: true,
-				Message: "Success notification disabled",
-			}, nil
-		}
-		return p.sendSuccessNotification(ctx, cfg, req.Context, req.DryRun)
-
+		status = StatusSuccess
 	case plugin.HookOnError:
-		if !cfg.NotifyOnError {
-			return &plugin.ExecuteResponse{
-				Success: true,
-				Message: "Error notification disabled",
-			}, nil
+		status = StatusError
+	case plugin.HookPostApprove:
+		if !cfg.NotifyOnApproval {
+			return p.skip(cfg, SkipApprovalDisabled, "Approval notification disabled"), nil
 		}
-		return p.sendErrorNotification(ctx, cfg, req.Context, req.DryRun)
-
+		status = StatusApproval
 	default:
+		if cfg.QuietUnhandled {
+			p.getLogger().Debug("hook not handled", "hook", string(req.Hook))
+			return &plugin.ExecuteResponse{Success: true}, nil
+		}
 		return &plugin.ExecuteResponse{
 			Success: true,
 			Message: fmt.Sprintf("Hook %s not handled", req.Hook),
 		}, nil
 	}
+
+	// force_status overrides hook-based routing for custom pipeline wiring
+	if cfg.ForceStatus != "" {
+		status = cfg.ForceStatus
+	}
+
+	// min_severity filters on top of the per-status notify_on_* switches
+	if !meetsMinSeverity(status, cfg.MinSeverity) {
+		return p.skip(cfg, SkipBelowSeverity, fmt.Sprintf("Notification below min_severity %s", cfg.MinSeverity)), nil
+	}
+
+	if p.hasSkipMarker(cfg, req.Context) {
+		return p.skip(cfg, SkipMarker, "Skipped via marker"), nil
+	}
+
+	switch status {
+	case StatusError:
+		if !cfg.NotifyOnError {
+			return p.skip(cfg, SkipErrorDisabled, "Error notification disabled"), nil
+		}
+		if err := p.resolveConfig(ctx, cfg); err != nil {
+			return configErrorResponse(err), nil
+		}
+		if cfg.CoalesceErrors {
+			return p.sendCoalescedErrorNotification(ctx, cfg, req.Context, req.DryRun)
+		}
+		return p.sendErrorNotification(ctx, cfg, req.Context, req.DryRun)
+
+	case StatusApproval:
+		if err := p.resolveConfig(ctx, cfg); err != nil {
+			return configErrorResponse(err), nil
+		}
+		return p.sendApprovalNotification(ctx, cfg, req.Context, req.DryRun)
+
+	default:
+		// A successful run ends the failures; send any still buffered
+		if cfg.CoalesceErrors {
+			if err := p.resolveConfig(ctx, cfg); err != nil {
+				return configErrorResponse(err), nil
+			}
+			p.flushBufferedErrors(ctx, cfg, req.Context.Version, req.DryRun)
+		}
+		if !cfg.NotifyOnSuccess {
+			return p.skip(cfg, SkipSuccessDisabled, "Success notification disabled"), nil
+		}
+		if cfg.SkipEmptyRelease && isEmptyRelease(req.Context) {
+			return p.skip(cfg, SkipEmptyRelease, "Empty release skipped"), nil
+		}
+		if err := p.resolveConfig(ctx, cfg); err != nil {
+			return configErrorResponse(err), nil
+		}
+		return p.sendSuccessNotification(ctx, cfg, req.Context, req.DryRun)
+	}
+}
+
+// hasSkipMarker reports whether the release notes or title contain
+// skip_marker, ignoring case. The title is checked before truncation.
+func (p *TeamsPlugin) hasSkipMarker(cfg *Config, releaseCtx plugin.ReleaseContext) bool {
+	marker := strings.ToLower(strings.TrimSpace(cfg.SkipMarker))
+	if marker == "" {
+		return false
+	}
+	return strings.Contains(strings.ToLower(releaseCtx.ReleaseNotes), marker) ||
+		strings.Contains(strings.ToLower(p.buildTitle(cfg.TitleTemplate, 0, releaseCtx)), marker)
+}
+
+// isEmptyRelease reports whether a release has no categorized changes and no notes.
+func isEmptyRelease(releaseCtx plugin.ReleaseContext) bool {
+	if strings.TrimSpace(releaseCtx.ReleaseNotes) != "" {
+		return false
+	}
+	c := releaseCtx.Changes
+	if c == nil {
+		return true
+	}
+	return len(c.Features)+len(c.Fixes)+len(c.Breaking)+len(c.Performance)+
+		len(c.Refactor)+len(c.Docs)+len(c.Other) == 0
+}
+
+// configErrorResponse builds the response for a config resolution failure.
+func configErrorResponse(err error) *plugin.ExecuteResponse {
+	return &plugin.ExecuteResponse{
+		Success: false,
+		Error:   fmt.Sprintf("failed to resolve config: %v", err),
+	}
+}
+
+// notification is a built card, without mentions, ready for dispatch.
+type notification struct {
+	status  string
+	version string
+	// release is the release the notification is about.
+	release plugin.ReleaseContext
+	body    []AdaptiveElement
+	actions []AdaptiveAction
+	// cards are extra attachments rendered as a carousel after the main card.
+	cards []AdaptiveCard
+	// mentioned holds mention users resolved by resolve_mentions.
+	mentioned map[string]TeamsMentionedUser
+	color     string
+	digest    string
+	// outputs are returned from dry runs.
+	outputs map[string]any
 }
 
 // sendSuccessNotification sends a success notification to Teams.
 func (p *TeamsPlugin) sendSuccessNotification(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
-	title := p.buildTitle(cfg.TitleTemplate, releaseCtx.Version)
+	return p.dispatch(ctx, cfg, p.buildSuccessNotification(cfg, releaseCtx), dryRun), nil
+}
+
+// sendErrorNotification sends an error notification to Teams.
+func (p *TeamsPlugin) sendErrorNotification(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	return p.dispatch(ctx, cfg, p.buildErrorNotification(cfg, releaseCtx), dryRun), nil
+}
+
+// buildSuccessNotification builds the success card.
+func (p *TeamsPlugin) buildSuccessNotification(cfg *Config, releaseCtx plugin.ReleaseContext) notification {
+	title := p.buildTitle(cfg.TitleTemplate, cfg.TitleMaxChars, releaseCtx)
+	// A template can render blank, e.g. one using only an empty field
+	if strings.TrimSpace(title) == "" {
+		title = p.buildTitle(DefaultTitleTemplate, cfg.TitleMaxChars, releaseCtx)
+	}
+	if cfg.DecoratePrerelease {
+		title = decoratePrerelease(title, releaseCtx.Version)
+	}
+	title = withIcon(cfg.SuccessIcon, title)
+	color := resolveCardColor(StatusSuccess, releaseCtx, cfg)
 
 	// Build card body elements
 	body := []AdaptiveElement{
@@ -228,42 +888,69 @@ func (p *TeamsPlugin) sendSuccessNotification(ctx context.Context, cfg *Config,
 			Text:   title,
 			Weight: "bolder",
 			Size:   "large",
-			Color:  "good",
+			Color:  headerColor(color),
 		},
 	}
 
+	if cfg.RelativeTime {
+		if releasedAt, ok := resolveReleasedAt(cfg, releaseCtx); ok {
+			body = append(body, AdaptiveElement{
+				Type:     "TextBlock",
+				Text:     "Released " + humanizeSince(releasedAt, p.currentTime()),
+				IsSubtle: true,
+				Spacing:  "none",
+			})
+		}
+	}
+
+	if stepper, ok := buildStepper(cfg.Stages, cfg.Stage, false); ok {
+		body = append(body, stepper)
+	}
+
 	// Add version info container
-	infoItems := []AdaptiveElement{
-		{
-			Type: "ColumnSet",
-			Columns: []ColumnDefinition{
-				{
-					Type:  "Column",
-					Width: "auto",
-					Items: []AdaptiveElement{
-						{Type: "TextBlock", Text: "Version:", Weight: "bolder"},
-						{Type: "TextBlock", Text: "Type:", Weight: "bolder"},
-						{Type: "TextBlock", Text: "Branch:", Weight: "bolder"},
-						{Type: "TextBlock", Text: "Tag:", Weight: "bolder"},
-					},
-				},
-				{
-					Type:  "Column",
-					Width: "stretch",
-					Items: []AdaptiveElement{
-						{Type: "TextBlock", Text: releaseCtx.Version},
-						{Type: "TextBlock", Text: cases.Title(language.English).String(releaseCtx.ReleaseType)},
-						{Type: "TextBlock", Text: releaseCtx.Branch},
-						{Type: "TextBlock", Text: releaseCtx.TagName},
-					},
-				},
-			},
-		},
+	facts := []infoFact{
+		{Label: cfg.label(LabelVersion), Value: releaseCtx.Version},
+		{Label: cfg.label(LabelType), Value: formatReleaseType(releaseCtx.ReleaseType)},
+		{Label: cfg.label(LabelBranch), Value: releaseCtx.Branch},
+		{Label: cfg.label(LabelTag), Value: releaseCtx.TagName},
+	}
+	if cfg.ShowPreviousVersion {
+		facts = append(facts, infoFact{Label: cfg.label(LabelPrevious), Value: previousVersionText(releaseCtx)})
+	}
+	if cfg.ReleaseTypeBadge {
+		badge := buildReleaseTypeBadge(normalizeReleaseType(releaseCtx.ReleaseType), facts[1].Value)
+		facts[1].Element = &badge
+	}
+	if cfg.ShowApprover {
+		if approval := resolveApproval(cfg, releaseCtx); approval != "" {
+			facts = append(facts, infoFact{Label: cfg.label(LabelApprovedBy), Value: approval})
+		}
 	}
-	body = append(body, infoItems...)
+	if cfg.ShowContributorCount {
+		if contributors := countContributors(releaseCtx.Changes); contributors > 0 {
+			facts = append(facts, infoFact{Label: cfg.label(LabelContributors), Value: "👥 " + pluralize(contributors, "contributor")})
+		}
+	}
+	if cfg.ShowCIProvider {
+		if provider := resolveCIProvider(cfg); provider != "" {
+			facts = append(facts, infoFact{Label: cfg.label(LabelCIProvider), Value: provider})
+		}
+	}
+	extraFacts, overflow := buildExtraFacts(cfg.ExtraFacts, cfg.MaxExtraFacts)
+	facts = append(facts, extraFacts...)
+	var sections []AdaptiveElement
+	if cfg.ShowInfo {
+		sections = append(sections, buildInfo(cfg, facts))
+		if overflow > 0 {
+			sections = append(sections, buildExtraFactsOverflow(overflow))
+		}
+	}
+
+	// Changes and changelog may be moved behind a ShowCard action
+	var details []AdaptiveElement
 
 	// Add changes summary if available
-	if releaseCtx.Changes != nil {
+	if cfg.ShowSummary && releaseCtx.Changes != nil {
 		features := len(releaseCtx.Changes.Features)
 		fixes := len(releaseCtx.Changes.Fixes)
 		breaking := len(releaseCtx.Changes.Breaking)
@@ -273,47 +960,128 @@ func (p *TeamsPlugin) sendSuccessNotification(ctx context.Context, cfg *Config,
 			summary += fmt.Sprintf(", **%d breaking changes**", breaking)
 		}
 
-		body = append(body, AdaptiveElement{
+		details = append(details, AdaptiveElement{
 			Type:      "TextBlock",
-			Text:      "Changes: " + summary,
+			Text:      cfg.label(LabelChanges) + ": " + summary,
 			Separator: true,
 			Spacing:   "medium",
 		})
 	}
 
+	// Link #N references in commit descriptions to the repository's issues
+	var issueRepoURL string
+	if cfg.LinkifyIssues {
+		issueRepoURL = repositoryWebURL(releaseCtx.RepositoryURL)
+	}
+
+	// List the changes themselves under scope (or category) headings
+	if cfg.GroupByScope {
+		details = append(details, buildChangeGroups(releaseCtx.Changes, true, cfg.CategoryEmoji, issueRepoURL)...)
+	} else if cfg.IncludeChangeDetails {
+		details = append(details, buildChangeDetails(releaseCtx.Changes, cfg.ChangeDetailsLimit, cfg.CategoryEmoji, issueRepoURL)...)
+	}
+
+	// Warn about upcoming removals
+	if cfg.ShowDeprecations {
+		if deprecations := collectDeprecations(releaseCtx.Changes); len(deprecations) > 0 {
+			details = append(details, buildDeprecationsSection(deprecations, issueRepoURL))
+		}
+	}
+
+	releaseURL := buildReleaseURL(releaseCtx)
+
 	// Add changelog if enabled
+	if cfg.IncludeChangelog && releaseCtx.ReleaseNotes == "" && cfg.EmptyChangelogText != "" {
+		details = append(details, AdaptiveElement{
+			Type:      "TextBlock",
+			Text:      html.EscapeString(cfg.EmptyChangelogText),
+			Wrap:      true,
+			IsSubtle:  true,
+			Separator: true,
+			Spacing:   "medium",
+		})
+	}
 	if cfg.IncludeChangelog && releaseCtx.ReleaseNotes != "" {
 		notes := releaseCtx.ReleaseNotes
-		// Truncate if too long (Teams has limits on card size)
-		if len(notes) > 2000 {
-			notes = notes[:2000] + "..."
+		// Notes captured from colored CLI output render escape codes as garbage
+		if cfg.StripANSI {
+			notes = stripANSI(notes)
+		}
+		truncated := false
+		// Truncate if too long (Teams has limits on card size). The raw notes
+		// are cut, so escaping or sanitizing never leaves a split entity.
+		if maxLength := cfg.changelogMaxLength(); utf8.RuneCountInString(notes) > maxLength {
+			notes = string([]rune(notes)[:maxLength]) + "..."
+			truncated = true
+		}
+		if cfg.RenderMarkdown {
+			notes = sanitizeMarkdown(notes)
+		} else {
+			// Escape HTML to prevent XSS attacks
+			notes = html.EscapeString(notes)
 		}
-		// Escape HTML to prevent XSS attacks
-		notes = html.EscapeString(notes)
 
-		body = append(body, AdaptiveElement{
+		details = append(details, AdaptiveElement{
 			Type:      "TextBlock",
 			Text:      notes,
 			Wrap:      true,
 			Separator: true,
 			Spacing:   "medium",
 		})
+
+		// Point readers at the complete notes when content was cut
+		notesURL := cfg.ReleaseNotesURL
+		if notesURL == "" {
+			notesURL = releaseURL
+		}
+		if truncated && notesURL != "" {
+			details = append(details, AdaptiveElement{
+				Type:    "TextBlock",
+				Text:    fmt.Sprintf("[📄 Full release notes →](%s)", notesURL),
+				Wrap:    true,
+				Spacing: "small",
+			})
+		}
 	}
 
-	// Add mention text if users specified
-	if len(cfg.MentionUsers) > 0 {
-		mentionText := p.buildMentionText(cfg.MentionUsers)
-		body = append(body, AdaptiveElement{
-			Type:    "TextBlock",
-			Text:    mentionText,
-			Spacing: "medium",
-		})
+	// Action.ShowCard requires Adaptive Cards 1.2
+	showDetails := cfg.ShowCardDetails && cfg.ShowActions && len(details) > 0 && cardVersionAtLeast(AdaptiveCardVersion, 1, 2)
+	if !showDetails {
+		sections = append(sections, details...)
+	}
+
+	// Upgrade instructions stay visible even when details are collapsed
+	if cfg.UpgradeInstructions != "" {
+		if instructions, err := renderUpgradeInstructions(cfg.UpgradeInstructions, releaseCtx, cfg.RenderMarkdown); err != nil {
+			p.getLogger().Warn("upgrade instructions skipped", "error", err.Error())
+		} else if instructions != "" {
+			sections = append(sections, buildUpgradeSection(instructions))
+		}
+	}
+
+	body = append(body, p.layoutSections(cfg, sections)...)
+	if cfg.ShowQRCode && releaseURL != "" {
+		if qr, err := buildQRCodeImage(releaseURL); err == nil {
+			body = append(body, qr)
+		} else {
+			p.getLogger().Warn("QR code skipped", "error", err.Error())
+		}
+	}
+	if cfg.EmbedMetadata {
+		body = append(body, buildMetadataElement(newReleaseMetadata(StatusSuccess, releaseCtx)))
 	}
 
 	// Build actions
 	var actions []AdaptiveAction
-	if releaseCtx.RepositoryURL != "" && releaseCtx.TagName != "" {
-		releaseURL := fmt.Sprintf("%s/releases/tag/%s", strings.TrimSuffix(releaseCtx.RepositoryURL, ".git"), releaseCtx.TagName)
+	if showDetails {
+		detailsCard := newAdaptiveCard(details, nil)
+		actions = append(actions, AdaptiveAction{
+			Type:  "Action.ShowCard",
+			Title: "Show details",
+			Card:  &detailsCard,
+		})
+	}
+	if cfg.ShowActions && releaseURL != "" {
 		actions = append(actions, AdaptiveAction{
 			Type:  "Action.OpenUrl",
 			Title: "View Release",
@@ -321,35 +1089,31 @@ func (p *TeamsPlugin) sendSuccessNotification(ctx context.Context, cfg *Config,
 		})
 	}
 
-	// Build the message
-	msg := p.buildTeamsMessage(body, actions, cfg.MentionUsers, ColorSuccess)
-
-	if dryRun {
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: "Would send Teams success notification",
-			Outputs: map[string]any{
-				"version": releaseCtx.Version,
-			},
-		}, nil
+	// One carousel card per configured component
+	var cards []AdaptiveCard
+	for _, component := range cfg.Components {
+		cards = append(cards, buildComponentCard(component))
 	}
 
-	if err := p.sendMessage(ctx, cfg.WebhookURL, msg); err != nil {
-		return &plugin.ExecuteResponse{
-			Success: false,
-			Error:   fmt.Sprintf("failed to send Teams message: %v", err),
-		}, nil
+	return notification{
+		status:  StatusSuccess,
+		version: releaseCtx.Version,
+		release: releaseCtx,
+		body:    body,
+		actions: actions,
+		cards:   cards,
+		color:   color,
+		digest:  buildDigestText(StatusSuccess, releaseCtx, releaseURL),
+		outputs: map[string]any{
+			"version": releaseCtx.Version,
+		},
 	}
-
-	return &plugin.ExecuteResponse{
-		Success: true,
-		Message: "Sent Teams success notification",
-	}, nil
 }
 
-// sendErrorNotification sends an error notification to Teams.
-func (p *TeamsPlugin) sendErrorNotification(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
-	title := fmt.Sprintf("Release %s Failed", releaseCtx.Version)
+// buildErrorNotification builds the error card.
+func (p *TeamsPlugin) buildErrorNotification(cfg *Config, releaseCtx plugin.ReleaseContext) notification {
+	title := withIcon(cfg.ErrorIcon, fmt.Sprintf("Release %s Failed", releaseCtx.Version))
+	color := resolveCardColor(StatusError, releaseCtx, cfg)
 
 	// Build card body elements
 	body := []AdaptiveElement{
@@ -358,89 +1122,390 @@ func (p *TeamsPlugin) sendErrorNotification(ctx context.Context, cfg *Config, re
 			Text:   title,
 			Weight: "bolder",
 			Size:   "large",
-			Color:  "attention",
-		},
-		{
-			Type: "ColumnSet",
-			Columns: []ColumnDefinition{
-				{
-					Type:  "Column",
-					Width: "auto",
-					Items: []AdaptiveElement{
-						{Type: "TextBlock", Text: "Version:", Weight: "bolder"},
-						{Type: "TextBlock", Text: "Branch:", Weight: "bolder"},
-					},
-				},
-				{
-					Type:  "Column",
-					Width: "stretch",
-					Items: []AdaptiveElement{
-						{Type: "TextBlock", Text: releaseCtx.Version},
-						{Type: "TextBlock", Text: releaseCtx.Branch},
-					},
-				},
-			},
+			Color:  headerColor(color),
 		},
 	}
 
-	// Add mention text if users specified
-	if len(cfg.MentionUsers) > 0 {
-		mentionText := p.buildMentionText(cfg.MentionUsers)
-		body = append(body, AdaptiveElement{
-			Type:    "TextBlock",
-			Text:    mentionText,
-			Spacing: "medium",
+	if stepper, ok := buildStepper(cfg.Stages, cfg.Stage, true); ok {
+		body = append(body, stepper)
+	}
+
+	var sections []AdaptiveElement
+	if cfg.ShowInfo {
+		sections = append(sections, buildInfo(cfg, []infoFact{
+			{Label: cfg.label(LabelVersion), Value: releaseCtx.Version},
+			{Label: cfg.label(LabelBranch), Value: releaseCtx.Branch},
+		}))
+	}
+
+	// Show what failed, with secrets masked before escaping
+	if errMsg := strings.TrimSpace(releaseCtx.Environment[EnvErrorMessage]); errMsg != "" {
+		sections = append(sections, AdaptiveElement{
+			Type:      "TextBlock",
+			Text:      html.EscapeString(cfg.redactErrorText(errMsg)),
+			Wrap:      true,
+			Separator: true,
+			Spacing:   "medium",
+		})
+	}
+	body = append(body, p.layoutSections(cfg, sections)...)
+	if cfg.EmbedMetadata {
+		body = append(body, buildMetadataElement(newReleaseMetadata(StatusError, releaseCtx)))
+	}
+
+	// Link straight to the failing job's logs
+	var actions []AdaptiveAction
+	if logsURL := p.resolveLogsURL(cfg, releaseCtx); cfg.ShowActions && logsURL != "" {
+		actions = append(actions, AdaptiveAction{
+			Type:  "Action.OpenUrl",
+			Title: "View Failed Job",
+			URL:   logsURL,
 		})
 	}
 
-	msg := p.buildTeamsMessage(body, nil, cfg.MentionUsers, ColorError)
+	return notification{
+		status:  StatusError,
+		actions: actions,
+		version: releaseCtx.Version,
+		release: releaseCtx,
+		body:    body,
+		color:   color,
+		digest:  buildDigestText(StatusError, releaseCtx, ""),
+	}
+}
+
+// dispatch adds mentions to a built notification and sends it, or reports
+// what would be sent in dry-run mode. When mentions are chunked, one card is
+// sent per mention group.
+func (p *TeamsPlugin) dispatch(ctx context.Context, cfg *Config, n notification, dryRun bool) *plugin.ExecuteResponse {
+	muted := cfg.muteMentions(n)
+	if !dryRun && !muted {
+		n.mentioned = p.resolveMentions(ctx, cfg)
+	}
+
+	groups := p.mentionGroups(cfg)
+	if muted {
+		groups = [][]string{nil}
+	}
+	msgs := make([]TeamsMessage, 0, len(groups))
+	for _, mentions := range groups {
+		msgs = append(msgs, p.buildNotificationMessage(cfg, n, mentions))
+	}
+	p.getLogger().Debug("card built", "kind", n.status, "elements", len(n.body), "actions", len(n.actions), "cards", len(msgs))
+
+	suffix := ""
+	if len(msgs) > 1 {
+		suffix = fmt.Sprintf(" (%d cards)", len(msgs))
+	}
 
 	if dryRun {
+		// Encode every card as a real send would, so payload bugs fail the dry run
+		for i, msg := range msgs {
+			if err := p.checkPayload(cfg, msg); err != nil {
+				if len(msgs) > 1 {
+					err = fmt.Errorf("card %d of %d: %w", i+1, len(msgs), err)
+				}
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("dry run: %v", err),
+				}
+			}
+		}
 		return &plugin.ExecuteResponse{
 			Success: true,
-			Message: "Would send Teams error notification",
-		}, nil
+			Message: fmt.Sprintf("Would send Teams %s notification%s", n.status, suffix),
+			Outputs: n.outputs,
+		}
 	}
 
-	if err := p.sendMessage(ctx, cfg.WebhookURL, msg); err != nil {
-		return &plugin.ExecuteResponse{
-			Success: false,
-			Error:   fmt.Sprintf("failed to send Teams message: %v", err),
-		}, nil
+	// Redeliver messages spooled by earlier invocations first, in order
+	if cfg.SpoolDir != "" {
+		p.drainSpool(ctx, cfg)
 	}
 
-	return &plugin.ExecuteResponse{
+	targets := cfg.webhookTargets(n.status)
+
+	// Skip notifications already sent recently, e.g. when a hook is replayed
+	var idempotency *lruCache
+	if cfg.Idempotent {
+		idempotency = p.getIdempotencyCache(cfg)
+		pending := targets[:0:0]
+		for _, webhookURL := range targets {
+			if !idempotency.contains(idempotencyKey(webhookURL, n)) {
+				pending = append(pending, webhookURL)
+			}
+		}
+		if len(pending) == 0 {
+			return p.skip(cfg, SkipDuplicate, fmt.Sprintf("Duplicate Teams %s notification skipped", n.status))
+		}
+		targets = pending
+	}
+
+	// Suppress notifications to webhooks notified within cooldown_seconds.
+	// Errors that bypass the cooldown still start a new one.
+	var cooldown *cooldownState
+	now := p.currentTime()
+	window := time.Duration(cfg.CooldownSeconds) * time.Second
+	if cfg.CooldownSeconds > 0 {
+		cooldown = p.getCooldownState(cfg)
+	}
+	if cooldown != nil && cfg.cooldownApplies(n.status) {
+		pending := targets[:0:0]
+		for _, webhookURL := range targets {
+			if !cooldown.within(webhookURL, now, window) {
+				pending = append(pending, webhookURL)
+			}
+		}
+		if len(pending) == 0 {
+			return p.skip(cfg, SkipCooldown, "Within cooldown")
+		}
+		targets = pending
+	}
+
+	attempts := make([]int, len(targets))
+	errs := p.fanOut(cfg, targets, func(i int, webhookURL string) error {
+		ctx, counter := withAttemptCounter(ctx)
+		defer func() { attempts[i] = int(counter.Load()) }()
+		if err := p.sendToWebhook(ctx, cfg, webhookURL, msgs); err != nil {
+			return err
+		}
+		if idempotency != nil {
+			idempotency.add(idempotencyKey(webhookURL, n))
+		}
+		if cooldown != nil {
+			cooldown.record(webhookURL, now)
+		}
+		return nil
+	})
+	if cooldown != nil {
+		if err := cooldown.save(now, window); err != nil {
+			p.getLogger().Warn("cooldown state not saved", "error", err.Error())
+		}
+	}
+
+	var outputs map[string]any
+	if len(targets) == 1 {
+		if errs[0] != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   errs[0].Error(),
+			}
+		}
+	} else {
+		// Report each target so callers can see which webhooks failed
+		outputs = map[string]any{"targets": fanOutResults(targets, errs, attempts)}
+		failures := fanOutFailures(targets, errs)
+		if !fanOutSucceeded(cfg, len(targets), len(failures)) {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error: fmt.Sprintf("failed to send Teams %s notification to %d of %d webhooks: %s",
+					n.status, len(failures), len(targets), strings.Join(failures, "; ")),
+				Outputs: outputs,
+			}
+		}
+		if len(failures) > 0 {
+			p.getLogger().Warn("some webhooks failed; notification counted as sent under fanout_success_policy any",
+				"failed", len(failures), "webhooks", len(targets), "errors", strings.Join(failures, "; "))
+			suffix += fmt.Sprintf(" to %d of %d webhooks", len(targets)-len(failures), len(targets))
+		} else {
+			suffix += fmt.Sprintf(" to %d webhooks", len(targets))
+		}
+	}
+
+	resp := &plugin.ExecuteResponse{
 		Success: true,
-		Message: "Sent Teams error notification",
-	}, nil
+		Message: p.buildResultMessage(cfg, n, len(targets), fmt.Sprintf("Sent Teams %s notification%s", n.status, suffix)),
+		Outputs: outputs,
+	}
+	p.sendDigest(ctx, cfg, resp, n.digest)
+	return resp
 }
 
-// buildTeamsMessage builds the complete Teams message with Adaptive Card.
-func (p *TeamsPlugin) buildTeamsMessage(body []AdaptiveElement, actions []AdaptiveAction, mentionUsers []string, _ string) TeamsMessage {
-	card := AdaptiveCard{
+// sendToWebhook sends the cards of a notification to one webhook, in order.
+// Transient failures are spooled for redelivery when spool_dir is set.
+func (p *TeamsPlugin) sendToWebhook(ctx context.Context, cfg *Config, webhookURL string, msgs []TeamsMessage) error {
+	for i, msg := range msgs {
+		if i > 0 {
+			// Stay under the webhook rate limit when sending several cards
+			if err := p.sleep(ctx, mentionChunkInterval); err != nil {
+				return fmt.Errorf("failed to send Teams message: %w", err)
+			}
+		}
+		if err := p.deliver(ctx, cfg, webhookURL, msg); err != nil {
+			errMsg := fmt.Sprintf("failed to send Teams message: %v", err)
+			if len(msgs) > 1 {
+				errMsg = fmt.Sprintf("failed to send Teams message (card %d of %d): %v", i+1, len(msgs), err)
+			}
+			// Keep transient failures for redelivery by a later invocation
+			if cfg.SpoolDir != "" && isRetryable(err) {
+				if _, spoolErr := spoolMessage(cfg.SpoolDir, webhookURL, msg); spoolErr != nil {
+					p.getLogger().Error("failed to spool Teams message", "error", spoolErr.Error())
+				} else {
+					errMsg += " (spooled for redelivery)"
+				}
+			}
+			return errors.New(errMsg)
+		}
+	}
+	return nil
+}
+
+// buildNotificationMessage builds the Teams message for a notification with the given mentions.
+func (p *TeamsPlugin) buildNotificationMessage(cfg *Config, n notification, mentions []string) TeamsMessage {
+	body := n.body
+	actions := n.actions
+
+	// Never post a card with nothing to read
+	if !hasVisibleText(body) {
+		body = append([]AdaptiveElement{minimalBlock(n)}, body...)
+	}
+
+	// Webhooks have no importance flag, so it is shown on the card itself
+	if banner, ok := buildImportanceBanner(cfg.Importance); ok {
+		body = append([]AdaptiveElement{banner}, body...)
+	}
+
+	// Teams renders at most a handful of actions; drop the lowest-priority extras
+	if cfg.MaxActions > 0 && len(actions) > cfg.MaxActions {
+		dropped := len(actions) - cfg.MaxActions
+		actions = actions[:cfg.MaxActions]
+		body = append(body[:len(body):len(body)], AdaptiveElement{
+			Type:     "TextBlock",
+			Text:     fmt.Sprintf("and %d more actions", dropped),
+			IsSubtle: true,
+			Spacing:  "small",
+		})
+		p.getLogger().Debug("actions capped", "max_actions", cfg.MaxActions, "dropped", dropped)
+	}
+
+	// ActionSet is part of Adaptive Cards 1.2
+	if cfg.ActionsPosition == ActionsPositionTop && len(actions) > 0 && cardVersionAtLeast(AdaptiveCardVersion, 1, 2) {
+		body = append([]AdaptiveElement{{Type: "ActionSet", Actions: actions}}, body...)
+		actions = nil
+	}
+
+	// Add mention text if any valid users are specified
+	if mentionText := p.buildMentionText(mentions); mentionText != "" {
+		body = append(body[:len(body):len(body)], AdaptiveElement{
+			Type:    "TextBlock",
+			Text:    mentionText,
+			Spacing: "medium",
+		})
+	}
+
+	if cfg.ExpireAfterSeconds > 0 {
+		expiresAt := p.currentTime().Add(time.Duration(cfg.ExpireAfterSeconds) * time.Second)
+		body = append(body[:len(body):len(body)], buildExpiryNote(expiresAt, loadTimezone(cfg.Timezone)))
+	}
+
+	if cfg.FooterTimestamp {
+		body = append(body[:len(body):len(body)], buildTimestampFooter(p.currentTime(), loadTimezone(cfg.Timezone)))
+	}
+
+	msg := p.buildTeamsMessage(body, actions, mentions, n.color)
+	applyResolvedMentions(&msg, n.mentioned)
+	msg.Attachments[0].Content.Lang = cfg.Language
+	msg.Attachments[0].Content.RTL = cfg.RTL
+	if cfg.BackgroundImageURL != "" {
+		msg.Attachments[0].Content.BackgroundImage = cfg.BackgroundImageURL
+	}
+
+	if len(n.cards) > 0 {
+		cards := make([]AdaptiveCard, len(n.cards))
+		for i, card := range n.cards {
+			card.Lang = cfg.Language
+			card.RTL = cfg.RTL
+			cards[i] = card
+		}
+		if dropped := appendComponentCards(&msg, cards); dropped > 0 {
+			p.getLogger().Warn("component cards dropped to fit the Teams payload limit", "dropped", dropped, "limit", MaxPayloadBytes)
+		}
+	}
+	return msg
+}
+
+// hasVisibleText reports whether any visible element, including nested ones,
+// has non-blank text.
+func hasVisibleText(elements []AdaptiveElement) bool {
+	for _, e := range elements {
+		if e.IsVisible != nil && !*e.IsVisible {
+			continue
+		}
+		if strings.TrimSpace(e.Text) != "" || hasVisibleText(e.Items) {
+			return true
+		}
+		for _, column := range e.Columns {
+			if hasVisibleText(column.Items) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// minimalBlock is the title shown when a notification's body would otherwise be blank.
+func minimalBlock(n notification) AdaptiveElement {
+	outcome := map[string]string{
+		StatusSuccess:  "published",
+		StatusError:    "failed",
+		StatusApproval: "approved",
+	}[n.status]
+	return AdaptiveElement{
+		Type:   "TextBlock",
+		Text:   strings.TrimSpace(fmt.Sprintf("Release %s %s", n.version, outcome)),
+		Weight: "bolder",
+		Size:   "large",
+		Wrap:   true,
+	}
+}
+
+// newAdaptiveCard returns a card at AdaptiveCardVersion.
+func newAdaptiveCard(body []AdaptiveElement, actions []AdaptiveAction) AdaptiveCard {
+	return AdaptiveCard{
 		Type:    "AdaptiveCard",
-		Version: "1.2",
+		Version: AdaptiveCardVersion,
 		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
 		Body:    body,
 		Actions: actions,
 	}
+}
+
+// cardVersionAtLeast reports whether an Adaptive Card version such as "1.2"
+// is at least major.minor.
+func cardVersionAtLeast(version string, major, minor int) bool {
+	var gotMajor, gotMinor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &gotMajor, &gotMinor); err != nil {
+		return false
+	}
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
+// buildTeamsMessage builds the complete Teams message with Adaptive Card.
+func (p *TeamsPlugin) buildTeamsMessage(body []AdaptiveElement, actions []AdaptiveAction, mentionUsers []string, _ string) TeamsMessage {
+	card := newAdaptiveCard(body, actions)
 
 	// Add Teams-specific entities for mentions
 	if len(mentionUsers) > 0 {
 		entities := make([]TeamsEntity, 0, len(mentionUsers))
 		for _, email := range mentionUsers {
+			if !isValidMention(email) {
+				continue
+			}
 			entities = append(entities, TeamsEntity{
 				Type: "mention",
-				Text: fmt.Sprintf("<at>%s</at>", email),
+				Text: mentionTag(email),
 				Mentioned: &TeamsMentionedUser{
 					ID:   email,
 					Name: email,
 				},
 			})
 		}
-		card.MSTeams = &MSTeamsConfig{
-			Width:    "Full",
-			Entities: entities,
+		// An empty entity list confuses Teams, so only set it for valid mentions
+		if len(entities) > 0 {
+			card.MSTeams = &MSTeamsConfig{
+				Width:    "Full",
+				Entities: entities,
+			}
 		}
 	}
 
@@ -455,30 +1520,322 @@ func (p *TeamsPlugin) buildTeamsMessage(body []AdaptiveElement, actions []Adapti
 	}
 }
 
-// buildTitle builds the card title from template.
-func (p *TeamsPlugin) buildTitle(template, version string) string {
+// changelogMaxLength returns the release notes truncation length, falling
+// back to the default for configs built without parseConfig.
+func (cfg *Config) changelogMaxLength() int {
+	if cfg.ChangelogMaxLength <= 0 {
+		return DefaultChangelogMaxLength
+	}
+	return cfg.ChangelogMaxLength
+}
+
+// webhookFor returns the webhook a notification with the given status is sent to.
+func (cfg *Config) webhookFor(status string) string {
+	switch {
+	case status == StatusSuccess && cfg.WebhookURLSuccess != "":
+		return cfg.WebhookURLSuccess
+	case status == StatusError && cfg.WebhookURLError != "":
+		return cfg.WebhookURLError
+	default:
+		return cfg.WebhookURL
+	}
+}
+
+// infoFact is a label/value row in the card's info block.
+type infoFact struct {
+	Label string
+	Value string
+	// Element replaces the plain value TextBlock when set.
+	Element *AdaptiveElement
+}
+
+// buildInfo renders facts in the configured layout.
+func buildInfo(cfg *Config, facts []infoFact) AdaptiveElement {
+	if cfg.Layout == LayoutFacts {
+		return buildInfoFactSet(facts)
+	}
+	return buildInfoColumns(facts)
+}
+
+// buildInfoFactSet renders facts as a FactSet. FactSet values are plain text,
+// so a custom value element (such as the release type badge) shows its value.
+func buildInfoFactSet(facts []infoFact) AdaptiveElement {
+	set := make([]Fact, 0, len(facts))
+	for _, f := range facts {
+		set = append(set, Fact{Title: f.Label, Value: f.Value})
+	}
+	return AdaptiveElement{Type: "FactSet", Facts: set}
+}
+
+// buildInfoColumns renders facts as a two-column ColumnSet of labels and values.
+func buildInfoColumns(facts []infoFact) AdaptiveElement {
+	labels := make([]AdaptiveElement, 0, len(facts))
+	values := make([]AdaptiveElement, 0, len(facts))
+	for _, f := range facts {
+		labels = append(labels, AdaptiveElement{Type: "TextBlock", Text: f.Label + ":", Weight: "bolder"})
+		if f.Element != nil {
+			values = append(values, *f.Element)
+			continue
+		}
+		values = append(values, AdaptiveElement{Type: "TextBlock", Text: f.Value})
+	}
+	return AdaptiveElement{
+		Type: "ColumnSet",
+		Columns: []ColumnDefinition{
+			{Type: "Column", Width: "auto", Items: labels},
+			{Type: "Column", Width: "stretch", Items: values},
+		},
+	}
+}
+
+// normalizeReleaseType trims and lowercases a release type.
+func normalizeReleaseType(releaseType string) string {
+	return strings.ToLower(strings.TrimSpace(releaseType))
+}
+
+// formatReleaseType returns the display form of a release type,
+// or "Unknown" when it is empty.
+func formatReleaseType(releaseType string) string {
+	normalized := normalizeReleaseType(releaseType)
+	if normalized == "" {
+		return "Unknown"
+	}
+	return cases.Title(language.English).String(normalized)
+}
+
+// importanceBanners are the container style and text of the banner shown
+// for each importance above normal.
+var importanceBanners = map[string]struct{ style, text string }{
+	ImportanceHigh:   {style: "warning", text: "High importance"},
+	ImportanceUrgent: {style: "attention", text: "Urgent"},
+}
+
+// buildImportanceBanner renders a full-width banner for high and urgent
+// messages. It reports false for normal importance.
+func buildImportanceBanner(importance string) (AdaptiveElement, bool) {
+	banner, ok := importanceBanners[importance]
+	if !ok {
+		return AdaptiveElement{}, false
+	}
+	return AdaptiveElement{
+		Type:  "Container",
+		Style: banner.style,
+		Bleed: true,
+		Items: []AdaptiveElement{
+			{Type: "TextBlock", Text: banner.text, Weight: "bolder", Wrap: true},
+		},
+	}, true
+}
+
+// releaseTypeBadgeStyles maps release types to Adaptive Card container styles,
+// matching the card colors: green (ColorSuccess) for patch, Teams blue
+// (DefaultThemeColor) for minor and orange for major.
+var releaseTypeBadgeStyles = map[string]string{
+	"patch": "good",
+	"minor": "accent",
+	"major": "warning",
+}
+
+// buildReleaseTypeBadge renders the release type as a small colored pill.
+func buildReleaseTypeBadge(releaseType, label string) AdaptiveElement {
+	style, ok := releaseTypeBadgeStyles[releaseType]
+	if !ok {
+		style = "emphasis"
+	}
+	return AdaptiveElement{
+		Type:  "Container",
+		Style: style,
+		Items: []AdaptiveElement{
+			{Type: "TextBlock", Text: label, Weight: "bolder", Size: "small"},
+		},
+	}
+}
+
+// resolveLogsURL returns the failed job's logs URL from logs_url_template or
+// the release environment, or "" when neither yields an HTTPS URL on an
+// allowed action host.
+func (p *TeamsPlugin) resolveLogsURL(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	logsURL := releaseCtx.Environment[EnvLogsURL]
+	if cfg.LogsURLTemplate != "" {
+		rendered, err := executeTemplate("logs_url", cfg.LogsURLTemplate, newTemplateData(releaseCtx))
+		if err != nil {
+			p.getLogger().Warn("logs_url_template failed to render; omitting the logs link", "error", err.Error())
+			return ""
+		}
+		logsURL = rendered
+	}
+	if logsURL == "" {
+		return ""
+	}
+	if err := validateHTTPSURL(logsURL); err != nil {
+		p.getLogger().Warn("ignoring invalid logs URL", "error", err.Error())
+		return ""
+	}
+	// RELICTA_LOGS_URL and rendered templates are only known at send time
+	if err := validateActionHost(logsURL, cfg.AllowedActionHosts); err != nil {
+		p.getLogger().Warn("ignoring logs URL", "error", err.Error())
+		return ""
+	}
+	return logsURL
+}
+
+// buildReleaseURL returns the release page URL, or "" when it cannot be derived.
+func buildReleaseURL(releaseCtx plugin.ReleaseContext) string {
+	if releaseCtx.RepositoryURL == "" || releaseCtx.TagName == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/releases/tag/%s", repositoryWebURL(releaseCtx.RepositoryURL), releaseCtx.TagName)
+}
+
+// layoutSections returns the card sections either as-is or, when grouped
+// layout is enabled, wrapped in a single bordered container.
+func (p *TeamsPlugin) layoutSections(cfg *Config, sections []AdaptiveElement) []AdaptiveElement {
+	if !cfg.GroupedLayout || len(sections) == 0 {
+		return sections
+	}
+	return []AdaptiveElement{
+		{
+			Type:       "Container",
+			Style:      "emphasis",
+			ShowBorder: true,
+			Spacing:    "medium",
+			Items:      sections,
+		},
+	}
+}
+
+// buildTitle builds the card title from template, falling back to the
+// default title when the template fails to render. Titles longer than
+// maxChars runes are cut with an ellipsis; zero disables the limit.
+func (p *TeamsPlugin) buildTitle(template string, maxChars int, releaseCtx plugin.ReleaseContext) string {
 	if template == "" {
 		template = DefaultTitleTemplate
 	}
-	return strings.ReplaceAll(template, "{{version}}", version)
+	title, err := renderTemplate(template, releaseCtx)
+	if err != nil {
+		p.getLogger().Warn("title_template failed to render; using the default title", "error", err.Error())
+		title, _ = renderTemplate(DefaultTitleTemplate, releaseCtx)
+	}
+	return truncateTitle(title, maxChars)
 }
 
-// buildMentionText builds the mention text for users.
-func (p *TeamsPlugin) buildMentionText(users []string) string {
-	if len(users) == 0 {
-		return ""
+// truncateTitle cuts title to at most maxChars runes, ending in an ellipsis.
+func truncateTitle(title string, maxChars int) string {
+	if maxChars <= 0 || utf8.RuneCountInString(title) <= maxChars {
+		return title
+	}
+	runes := []rune(title)[:maxChars-1]
+	return strings.TrimRight(string(runes), " ") + "…"
+}
+
+// buildResultMessage renders result_message_template for a sent
+// notification, returning defaultMessage when it is unset or fails to render.
+func (p *TeamsPlugin) buildResultMessage(cfg *Config, n notification, webhooks int, defaultMessage string) string {
+	if cfg.ResultMessageTemplate == "" {
+		return defaultMessage
+	}
+	message, err := executeTemplate("result_message", cfg.ResultMessageTemplate, newResultMessageData(n.status, webhooks, n.release))
+	if err != nil {
+		p.getLogger().Warn("result_message_template failed to render; using the default message", "error", err.Error())
+		return defaultMessage
 	}
+	return message
+}
+
+// withIcon prefixes a header title with an optional icon.
+func withIcon(icon, title string) string {
+	if icon == "" {
+		return title
+	}
+	return icon + " " + title
+}
 
+// buildMentionText builds the mention text for users, or "" when none are valid.
+func (p *TeamsPlugin) buildMentionText(users []string) string {
 	var mentions []string
 	for _, user := range users {
-		mentions = append(mentions, fmt.Sprintf("<at>%s</at>", user))
+		if !isValidMention(user) {
+			continue
+		}
+		mentions = append(mentions, mentionTag(user))
+	}
+	if len(mentions) == 0 {
+		return ""
 	}
 	return "cc: " + strings.Join(mentions, " ")
 }
 
-// sendMessage sends a message to Teams.
+// deliver sends the message to the given webhook, retrying transient failures.
+func (p *TeamsPlugin) deliver(ctx context.Context, cfg *Config, webhookURL string, msg TeamsMessage) error {
+	return p.sendWithRetry(ctx, cfg, webhookURL, msg)
+}
+
+// sendMessage sends a message to Teams using the plugin's HTTP client.
 func (p *TeamsPlugin) sendMessage(ctx context.Context, webhookURL string, msg TeamsMessage) error {
+	return p.postMessage(ctx, p.getHTTPClient(), webhookURL, msg)
+}
+
+// postMessage sends a message to Teams using the given HTTP client.
+func (p *TeamsPlugin) postMessage(ctx context.Context, client HTTPClient, webhookURL string, msg TeamsMessage) error {
+	return p.sendRequest(ctx, client, webhookURL, msg, sendOptions{})
+}
+
+// sendOptions controls how sendRequest encodes a message and judges the response.
+type sendOptions struct {
+	// successCodes are the statuses that count as delivered; nil accepts 200–204.
+	successCodes []int
+	// pretty indents the JSON payload.
+	pretty bool
+}
+
+// sendOptionsFor derives send options from the config.
+func sendOptionsFor(cfg *Config) sendOptions {
+	return sendOptions{successCodes: cfg.SuccessStatusCodes, pretty: cfg.PrettyPayload}
+}
+
+// marshalPayload encodes a message, indented when pretty is set. Indentation
+// never pushes a message that fits over MaxPayloadBytes; such messages are
+// sent compact instead.
+func marshalPayload(msg TeamsMessage, pretty bool) ([]byte, error) {
 	payload, err := json.Marshal(msg)
+	if err != nil || !pretty {
+		return payload, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, payload, "", "  "); err != nil {
+		return nil, err
+	}
+	if indented.Len() > MaxPayloadBytes && len(payload) <= MaxPayloadBytes {
+		return payload, nil
+	}
+	return indented.Bytes(), nil
+}
+
+// encodePayload encodes a message with marshalFunc, or marshalPayload by default.
+func (p *TeamsPlugin) encodePayload(msg TeamsMessage, pretty bool) ([]byte, error) {
+	if p.marshalFunc != nil {
+		return p.marshalFunc(msg, pretty)
+	}
+	return marshalPayload(msg, pretty)
+}
+
+// checkPayload encodes a message as it would be sent and checks it fits
+// the Teams size limit.
+func (p *TeamsPlugin) checkPayload(cfg *Config, msg TeamsMessage) error {
+	payload, err := p.encodePayload(msg, cfg.PrettyPayload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	if len(payload) > MaxPayloadBytes {
+		return fmt.Errorf("message is %d bytes, over the Teams limit of %d bytes", len(payload), MaxPayloadBytes)
+	}
+	return nil
+}
+
+// sendRequest posts a message to Teams, encoding it and judging the response
+// according to opts.
+func (p *TeamsPlugin) sendRequest(ctx context.Context, client HTTPClient, webhookURL string, msg TeamsMessage, opts sendOptions) error {
+	payload, err := p.encodePayload(msg, opts.pretty)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
@@ -489,21 +1846,78 @@ func (p *TeamsPlugin) sendMessage(ctx context.Context, webhookURL string, msg Te
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := p.getHTTPClient()
+	logger := p.getLogger()
+	host := redactWebhookURL(webhookURL)
+
+	if p.RequestInterceptor != nil {
+		if err := p.RequestInterceptor(req); err != nil {
+			logger.Error("Teams message aborted by request interceptor", "webhook", host, "error", redactError(err, webhookURL))
+			return fmt.Errorf("request aborted by interceptor: %w", err)
+		}
+		// The interceptor may have consumed the body
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return fmt.Errorf("failed to create request: %w", err)
+			}
+		}
+	}
+	logger.Debug("sending Teams message", "webhook", host, "bytes", len(payload))
+
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		logger.Error("Teams message failed", "webhook", host, "error", redactError(err, webhookURL))
+		return &transportError{
+			err:             fmt.Errorf("failed to send request: %w", err),
+			attemptTimedOut: ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded),
+		}
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// Teams returns 200 OK on success
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("teams returned status %d", resp.StatusCode)
+	// Connectors return 200 OK on success; Workflows return 202 Accepted
+	if !isSuccessStatus(resp.StatusCode, opts.successCodes) {
+		logger.Error("Teams message failed", "webhook", host, "status", resp.StatusCode)
+		return &statusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), p.currentTime()),
+		}
 	}
 
+	logger.Info("Teams message sent", "webhook", host, "status", resp.StatusCode)
 	return nil
 }
 
+// isSuccessStatus reports whether status is in accepted, or in 200–204 when
+// accepted is empty.
+func isSuccessStatus(status int, accepted []int) bool {
+	if len(accepted) == 0 {
+		return status >= http.StatusOK && status <= http.StatusNoContent
+	}
+	return slices.Contains(accepted, status)
+}
+
+// parseStatusCodes reads a list of HTTP status codes given as numbers or
+// numeric strings, skipping anything else.
+func parseStatusCodes(raw any) []int {
+	items, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	codes := make([]int, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case int:
+			codes = append(codes, v)
+		case float64:
+			codes = append(codes, int(v))
+		case string:
+			if code, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return codes
+}
+
 // getHTTPClient returns the HTTP client to use.
 func (p *TeamsPlugin) getHTTPClient() HTTPClient {
 	if p.httpClient != nil {
@@ -512,19 +1926,52 @@ func (p *TeamsPlugin) getHTTPClient() HTTPClient {
 	return defaultHTTPClient
 }
 
+// currentTime returns the current time from the plugin's clock.
+func (p *TeamsPlugin) currentTime() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// afterDelay runs f in its own goroutine once d has passed.
+func (p *TeamsPlugin) afterDelay(d time.Duration, f func()) {
+	if p.afterFunc != nil {
+		p.afterFunc(d, f)
+		return
+	}
+	time.AfterFunc(d, f)
+}
+
+// sleep pauses for d or until ctx is done.
+func (p *TeamsPlugin) sleep(ctx context.Context, d time.Duration) error {
+	if p.sleepFunc != nil {
+		return p.sleepFunc(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // parseConfig parses the plugin configuration.
 func (p *TeamsPlugin) parseConfig(raw map[string]any) *Config {
-	parser := helpers.NewConfigParser(raw)
+	cfg, _ := LoadConfig(raw)
+	return cfg
+}
 
-	return &Config{
-		WebhookURL:       parser.GetString("webhook_url", "TEAMS_WEBHOOK_URL", ""),
-		TitleTemplate:    parser.GetString("title_template", "", DefaultTitleTemplate),
-		IncludeChangelog: parser.GetBool("include_changelog", true),
-		ThemeColor:       parser.GetString("theme_color", "", DefaultThemeColor),
-		MentionUsers:     parser.GetStringSlice("mention_users", nil),
-		NotifyOnSuccess:  parser.GetBool("notify_on_success", true),
-		NotifyOnError:    parser.GetBool("notify_on_error", true),
+// isWorkflowsURL reports whether the webhook is a Teams Workflows (Power Automate) endpoint
+// rather than a legacy Office 365 connector.
+func isWorkflowsURL(webhookURL string) bool {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return false
 	}
+	return strings.HasSuffix(parsed.Hostname(), ".logic.azure.com")
 }
 
 // isValidMicrosoftHost checks if the host is a valid Microsoft domain for webhooks.
@@ -574,49 +2021,27 @@ func validateTeamsWebhookURL(webhookURL string) error {
 	return nil
 }
 
-// Validate validates the plugin configuration.
-func (p *TeamsPlugin) Validate(_ context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
-	vb := helpers.NewValidationBuilder()
-
-	// Get webhook URL with env fallback
-	parser := helpers.NewConfigParser(config)
-	webhook := parser.GetString("webhook_url", "TEAMS_WEBHOOK_URL", "")
-
-	// Check environment fallback if not in config
-	if webhook == "" {
-		webhook = os.Getenv("TEAMS_WEBHOOK_URL")
+// validateHTTPSURL validates that a link URL is an absolute HTTPS URL.
+func validateHTTPSURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
 	}
-
-	if webhook == "" {
-		vb.AddErrorWithCode("webhook_url",
-			"Teams webhook URL is required (set TEAMS_WEBHOOK_URL env var or configure webhook_url)",
-			"required")
-	} else {
-		if err := validateTeamsWebhookURL(webhook); err != nil {
-			vb.AddErrorWithCode("webhook_url", err.Error(), "format")
-		}
+	if parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("URL must be an absolute HTTPS URL")
 	}
+	return nil
+}
 
-	// Validate theme_color if provided
-	themeColor := parser.GetString("theme_color", "", "")
-	if themeColor != "" {
-		// Remove # if present
-		themeColor = strings.TrimPrefix(themeColor, "#")
-		if len(themeColor) != 6 {
-			vb.AddErrorWithCode("theme_color", "theme_color must be a 6-character hex color (e.g., '0076D7')", "format")
-		} else {
-			// Check if valid hex
-			for _, c := range themeColor {
-				isDigit := c >= '0' && c <= '9'
-				isLowerHex := c >= 'a' && c <= 'f'
-				isUpperHex := c >= 'A' && c <= 'F'
-				if !isDigit && !isLowerHex && !isUpperHex {
-					vb.AddErrorWithCode("theme_color", "theme_color must contain only hexadecimal characters", "format")
-					break
-				}
-			}
+// Validate validates the plugin configuration.
+func (p *TeamsPlugin) Validate(_ context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
+	_, errs := LoadConfig(config)
+	valid := true
+	for _, e := range errs {
+		if !isWarning(e) {
+			valid = false
+			break
 		}
 	}
-
-	return vb.Build(), nil
+	return &plugin.ValidateResponse{Valid: valid, Errors: errs}, nil
 }
@@ -6,13 +6,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
 )
@@ -30,6 +33,39 @@ func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	return nil, errors.New("DoFunc not set")
 }
 
+// recordingClient returns a mock client that responds 200 OK and stores each
+// request body, in arrival order, into bodies. It is safe for concurrent
+// fan-out; read bodies only after the send returns.
+func recordingClient(bodies *[][]byte) *MockHTTPClient {
+	var mu sync.Mutex
+	return &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			defer func() { _ = req.Body.Close() }()
+			mu.Lock()
+			*bodies = append(*bodies, body)
+			mu.Unlock()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		},
+	}
+}
+
+// decodeCard unmarshals a recorded payload and returns its first card.
+func decodeCard(t *testing.T, body []byte) AdaptiveCard {
+	t.Helper()
+	var msg TeamsMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	if len(msg.Attachments) == 0 {
+		t.Fatal("expected at least one attachment")
+	}
+	return msg.Attachments[0].Content
+}
+
 func TestGetInfo(t *testing.T) {
 	t.Parallel()
 
@@ -74,6 +110,7 @@ func TestGetInfo(t *testing.T) {
 	// Verify hooks
 	t.Run("hooks contains expected hooks", func(t *testing.T) {
 		expectedHooks := []plugin.Hook{
+			plugin.HookPostApprove,
 			plugin.HookPostPublish,
 			plugin.HookOnSuccess,
 			plugin.HookOnError,
@@ -589,7 +626,6 @@ func TestExecuteUnhandledHook(t *testing.T) {
 		{"PreNotes", plugin.HookPreNotes},
 		{"PostNotes", plugin.HookPostNotes},
 		{"PreApprove", plugin.HookPreApprove},
-		{"PostApprove", plugin.HookPostApprove},
 		{"PrePublish", plugin.HookPrePublish},
 	}
 
@@ -785,7 +821,7 @@ func TestBuildTitle(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := p.buildTitle(tt.template, tt.version)
+			got := p.buildTitle(tt.template, DefaultTitleMaxChars, plugin.ReleaseContext{Version: tt.version})
 			if got != tt.want {
 				t.Errorf("buildTitle(%q, %q) = %q, want %q", tt.template, tt.version, got, tt.want)
 			}
@@ -940,6 +976,16 @@ func TestSendMessageWithMockHTTPClient(t *testing.T) {
 			statusCode: http.StatusOK,
 			wantErr:    false,
 		},
+		{
+			name:       "success_202",
+			statusCode: http.StatusAccepted,
+			wantErr:    false,
+		},
+		{
+			name:       "success_204",
+			statusCode: http.StatusNoContent,
+			wantErr:    false,
+		},
 		{
 			name:           "error_400",
 			statusCode:     http.StatusBadRequest,
@@ -1032,6 +1078,73 @@ func TestSendMessageWithMockHTTPClient(t *testing.T) {
 	}
 }
 
+func TestSuccessStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		codes       []any
+		status      int
+		wantSuccess bool
+	}{
+		{name: "workflows_202_configured", codes: []any{202}, status: http.StatusAccepted, wantSuccess: true},
+		{name: "200_not_configured", codes: []any{202}, status: http.StatusOK, wantSuccess: false},
+		{name: "string_codes", codes: []any{"200", "202"}, status: http.StatusAccepted, wantSuccess: true},
+		{name: "default_accepts_202", status: http.StatusAccepted, wantSuccess: true},
+		{name: "default_rejects_206", status: http.StatusPartialContent, wantSuccess: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"}
+			if tt.codes != nil {
+				config["success_status_codes"] = tt.codes
+			}
+
+			var calls int
+			p := &TeamsPlugin{httpClient: statusSequenceClient(&calls, tt.status)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Errorf("expected Success=%v, got %+v", tt.wantSuccess, resp)
+			}
+		})
+	}
+}
+
+func TestValidateSuccessStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for name, tc := range map[string]struct {
+		codes     []any
+		wantValid bool
+	}{
+		"valid":     {codes: []any{200, 202.0}, wantValid: true},
+		"non_2xx":   {codes: []any{404}, wantValid: false},
+		"not_codes": {codes: []any{"ok"}, wantValid: false},
+	} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":          "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"success_status_codes": tc.codes,
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if resp.Valid != tc.wantValid {
+			t.Errorf("%s: expected Valid=%v, got %+v", name, tc.wantValid, resp.Errors)
+		}
+	}
+}
+
 func TestSendMessageNetworkError(t *testing.T) {
 	t.Parallel()
 
@@ -1228,6 +1341,7 @@ func TestExecuteWithFailedHTTPCall(t *testing.T) {
 					"webhook_url":       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
 					"notify_on_success": true,
 					"notify_on_error":   true,
+					"max_retries":       0,
 				},
 				Context: plugin.ReleaseContext{
 					Version:     "1.0.0",
@@ -1280,6 +1394,7 @@ func TestTeamsMessageStructure(t *testing.T) {
 		p := &TeamsPlugin{httpClient: mockClient}
 
 		cfg := &Config{
+			ShowActions:      true,
 			WebhookURL:       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
 			TitleTemplate:    "Release {{version}}",
 			IncludeChangelog: true,
@@ -1412,6 +1527,71 @@ func TestReleaseNoteTruncation(t *testing.T) {
 	}
 }
 
+func TestChangelogMaxLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		maxLength any
+		markdown  bool
+		notes     string
+		want      string
+	}{
+		{name: "default", notes: strings.Repeat("A", 3000), want: strings.Repeat("A", 2000) + "..."},
+		{name: "shorter", maxLength: 100, notes: strings.Repeat("A", 3000), want: strings.Repeat("A", 100) + "..."},
+		{name: "longer", maxLength: 5000, notes: strings.Repeat("A", 3000), want: strings.Repeat("A", 3000)},
+		{name: "cut_before_escaping", maxLength: 10, notes: "AAAAAAAAA&<b>", want: "AAAAAAAAA&amp;..."},
+		{name: "cut_on_rune_boundary", maxLength: 5, notes: "AAAé€x", want: "AAAé€..."},
+		{name: "cut_before_sanitizing", maxLength: 5, markdown: true, notes: "AAA < b", want: "AAA &lt;..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"}
+			if tt.maxLength != nil {
+				config["changelog_max_length"] = tt.maxLength
+			}
+			config["render_markdown"] = tt.markdown
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: tt.notes},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			var got string
+			for _, elem := range decodeCard(t, bodies[0]).Body {
+				if strings.HasPrefix(elem.Text, "AAA") {
+					got = elem.Text
+				}
+			}
+			if got != tt.want {
+				t.Errorf("expected notes of %d chars ending %q, got %d chars ending %q",
+					len(tt.want), tt.want[max(0, len(tt.want)-12):], len(got), got[max(0, len(got)-12):])
+			}
+		})
+	}
+
+	for maxLength, wantValid := range map[int]bool{1: true, 20000: true, 0: false, -5: false, 20001: false} {
+		resp, err := (&TeamsPlugin{}).Validate(context.Background(), map[string]any{
+			"webhook_url":          "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"changelog_max_length": maxLength,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("changelog_max_length=%d: expected Valid=%v, got %+v", maxLength, wantValid, resp.Errors)
+		}
+	}
+}
+
 func TestHTMLEscapingInReleaseNotes(t *testing.T) {
 	t.Parallel()
 
@@ -1473,6 +1653,78 @@ func TestHTMLEscapingInReleaseNotes(t *testing.T) {
 	}
 }
 
+func TestStripANSI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "colors", input: "\x1b[32m✓ Added\x1b[0m feature", want: "✓ Added feature"},
+		{name: "bold and reset", input: "\x1b[1;31mBREAKING\x1b[m change", want: "BREAKING change"},
+		{name: "cursor movement", input: "line\x1b[2K\x1b[1Gdone", want: "linedone"},
+		{name: "hyperlink", input: "\x1b]8;;https://example.com\x07link\x1b]8;;\x07", want: "link"},
+		{name: "plain text", input: "no [codes] here", want: "no [codes] here"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := stripANSI(tt.input); got != tt.want {
+				t.Errorf("stripANSI(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripANSIFromReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	notes := "\x1b[32mfeat:\x1b[0m add \x1b[1mexport\x1b[22m command"
+	tests := []struct {
+		name      string
+		stripANSI any
+		wantClean bool
+	}{
+		{name: "default", stripANSI: nil, wantClean: true},
+		{name: "disabled", stripANSI: false, wantClean: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{
+				"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			}
+			if tt.stripANSI != nil {
+				config["strip_ansi"] = tt.stripANSI
+			}
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0", Branch: "main", ReleaseNotes: notes},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			payload := string(bodies[0])
+			hasEscapes := strings.Contains(payload, `\u001b`)
+			if tt.wantClean && (hasEscapes || !strings.Contains(payload, "feat: add export command")) {
+				t.Errorf("expected escape codes removed with text preserved, got %s", payload)
+			}
+			if !tt.wantClean && !hasEscapes {
+				t.Errorf("expected escape codes to be kept, got %s", payload)
+			}
+		})
+	}
+}
+
 func TestNilConfig(t *testing.T) {
 	t.Parallel()
 
@@ -1724,6 +1976,7 @@ func TestChangeSummaryGeneration(t *testing.T) {
 			p := &TeamsPlugin{httpClient: mockClient}
 
 			cfg := &Config{
+				ShowSummary:     true,
 				WebhookURL:      "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
 				NotifyOnSuccess: true,
 			}
@@ -1881,3 +2134,1753 @@ func TestColorConstants(t *testing.T) {
 		}
 	})
 }
+
+func TestGroupedLayout(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version:      "1.2.0",
+		TagName:      "v1.2.0",
+		ReleaseType:  "minor",
+		Branch:       "main",
+		ReleaseNotes: "Some notes",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Description: "feat1"}},
+		},
+	}
+
+	t.Run("grouped", func(t *testing.T) {
+		var bodies [][]byte
+		p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+		cfg := &Config{
+			ShowSummary:      true,
+			ShowInfo:         true,
+			WebhookURL:       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			IncludeChangelog: true,
+			GroupedLayout:    true,
+		}
+
+		resp, err := p.sendSuccessNotification(context.Background(), cfg, releaseCtx, false)
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+		}
+
+		card := decodeCard(t, bodies[0])
+		if len(card.Body) != 2 {
+			t.Fatalf("expected title and one container, got %d elements", len(card.Body))
+		}
+
+		container := card.Body[1]
+		if container.Type != "Container" || container.Style != "emphasis" || !container.ShowBorder {
+			t.Errorf("expected bordered emphasis container, got %+v", container)
+		}
+		if len(container.Items) != 3 {
+			t.Fatalf("expected info, summary and changelog in container, got %d items", len(container.Items))
+		}
+		if container.Items[0].Type != "ColumnSet" {
+			t.Errorf("expected info ColumnSet first, got %q", container.Items[0].Type)
+		}
+		for i, item := range container.Items[1:] {
+			if !item.Separator {
+				t.Errorf("expected item %d to have a separator", i+1)
+			}
+		}
+
+		if !strings.Contains(string(bodies[0]), `"showBorder":true`) {
+			t.Error("expected showBorder in serialized payload")
+		}
+	})
+
+	t.Run("ungrouped_by_default", func(t *testing.T) {
+		var bodies [][]byte
+		p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+		cfg := &Config{
+			ShowSummary:      true,
+			ShowInfo:         true,
+			WebhookURL:       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			IncludeChangelog: true,
+		}
+
+		if _, err := p.sendSuccessNotification(context.Background(), cfg, releaseCtx, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		card := decodeCard(t, bodies[0])
+		if len(card.Body) != 4 {
+			t.Fatalf("expected 4 top-level elements, got %d", len(card.Body))
+		}
+		for _, elem := range card.Body {
+			if elem.Type == "Container" {
+				t.Error("expected no container without grouped_layout")
+			}
+		}
+	})
+}
+
+func TestFactSetLayout(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version:     "1.2.0",
+		TagName:     "v1.2.0",
+		ReleaseType: "minor",
+		Branch:      "main",
+	}
+
+	tests := []struct {
+		name     string
+		hook     plugin.Hook
+		layout   string
+		wantType string
+	}{
+		{name: "columns_by_default", hook: plugin.HookPostPublish, wantType: "ColumnSet"},
+		{name: "facts", hook: plugin.HookPostPublish, layout: "facts", wantType: "FactSet"},
+		{name: "facts_on_error", hook: plugin.HookOnError, layout: "facts", wantType: "FactSet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{
+				"webhook_url":        "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				"release_type_badge": true,
+			}
+			if tt.layout != "" {
+				config["layout"] = tt.layout
+			}
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{Hook: tt.hook, Config: config, Context: releaseCtx})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			info := decodeCard(t, bodies[0]).Body[1]
+			if info.Type != tt.wantType {
+				t.Fatalf("expected version info as %s, got %s", tt.wantType, info.Type)
+			}
+			if tt.wantType != "FactSet" {
+				return
+			}
+			if len(info.Columns) != 0 || len(info.Facts) == 0 || info.Facts[0] != (Fact{Title: "Version", Value: "1.2.0"}) {
+				t.Errorf("expected facts starting with the version, got %+v", info.Facts)
+			}
+			if tt.hook == plugin.HookPostPublish && info.Facts[1] != (Fact{Title: "Type", Value: "Minor"}) {
+				t.Errorf("expected the badged release type as plain text, got %+v", info.Facts[1])
+			}
+			if !strings.Contains(string(bodies[0]), `"facts":[{"title":"Version","value":"1.2.0"}`) {
+				t.Errorf("expected Adaptive Card fact JSON, got %s", bodies[0])
+			}
+		})
+	}
+
+	resp, err := (&TeamsPlugin{}).Validate(context.Background(), map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"layout":      "table",
+	})
+	if err != nil || resp.Valid {
+		t.Errorf("expected an unknown layout to be invalid, got %+v (err=%v)", resp, err)
+	}
+}
+
+func TestForceStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		hook        plugin.Hook
+		forceStatus string
+		wantMessage string
+		wantColor   string
+	}{
+		{
+			name:        "force_error_on_post_publish",
+			hook:        plugin.HookPostPublish,
+			forceStatus: "error",
+			wantMessage: "Sent Teams error notification",
+			wantColor:   "attention",
+		},
+		{
+			name:        "force_success_on_error",
+			hook:        plugin.HookOnError,
+			forceStatus: "success",
+			wantMessage: "Sent Teams success notification",
+			wantColor:   "good",
+		},
+		{
+			name:        "hook_routing_by_default",
+			hook:        plugin.HookPostPublish,
+			wantMessage: "Sent Teams success notification",
+			wantColor:   "good",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: tt.hook,
+				Config: map[string]any{
+					"webhook_url":  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"force_status": tt.forceStatus,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0", TagName: "v1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Message != tt.wantMessage {
+				t.Errorf("expected message %q, got %q", tt.wantMessage, resp.Message)
+			}
+
+			card := decodeCard(t, bodies[0])
+			if card.Body[0].Color != tt.wantColor {
+				t.Errorf("expected header color %q, got %q", tt.wantColor, card.Body[0].Color)
+			}
+		})
+	}
+
+	t.Run("unhandled_hook_ignores_force_status", func(t *testing.T) {
+		p := &TeamsPlugin{}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:   plugin.HookPreInit,
+			Config: map[string]any{"force_status": "error"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Message != "Hook pre-init not handled" {
+			t.Errorf("unexpected message %q", resp.Message)
+		}
+	})
+
+	t.Run("invalid_value_rejected_by_validate", func(t *testing.T) {
+		p := &TeamsPlugin{}
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"force_status": "warning",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid {
+			t.Error("expected invalid force_status to fail validation")
+		}
+	})
+}
+
+func TestTruncatedChangelogLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		notes    string
+		notesURL string
+		repoURL  string
+		wantLink string
+	}{
+		{
+			name:     "truncated_links_release_page",
+			notes:    strings.Repeat("A", 2500),
+			repoURL:  "https://github.com/test/repo",
+			wantLink: "https://github.com/test/repo/releases/tag/v1.0.0",
+		},
+		{
+			name:     "truncated_prefers_release_notes_url",
+			notes:    strings.Repeat("A", 2500),
+			notesURL: "https://docs.example.com/releases/1.0.0",
+			repoURL:  "https://github.com/test/repo",
+			wantLink: "https://docs.example.com/releases/1.0.0",
+		},
+		{
+			name:    "not_truncated_has_no_link",
+			notes:   "short notes",
+			repoURL: "https://github.com/test/repo",
+		},
+		{
+			name:  "truncated_without_any_url",
+			notes: strings.Repeat("A", 2500),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			cfg := &Config{
+				WebhookURL:       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				IncludeChangelog: true,
+				ReleaseNotesURL:  tt.notesURL,
+			}
+			releaseCtx := plugin.ReleaseContext{
+				Version:       "1.0.0",
+				TagName:       "v1.0.0",
+				RepositoryURL: tt.repoURL,
+				ReleaseNotes:  tt.notes,
+			}
+
+			if _, err := p.sendSuccessNotification(context.Background(), cfg, releaseCtx, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var link string
+			for _, elem := range decodeCard(t, bodies[0]).Body {
+				if strings.Contains(elem.Text, "Full release notes") {
+					link = elem.Text
+				}
+			}
+
+			if tt.wantLink == "" {
+				if link != "" {
+					t.Errorf("expected no full notes link, got %q", link)
+				}
+				return
+			}
+			if !strings.Contains(link, "("+tt.wantLink+")") {
+				t.Errorf("expected link to %q, got %q", tt.wantLink, link)
+			}
+		})
+	}
+}
+
+func TestSkipMarker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   map[string]any
+		notes    string
+		wantSent bool
+	}{
+		{name: "no_marker", notes: "feat: add export", wantSent: true},
+		{name: "default_marker", notes: "chore: bump deps [skip-teams]", wantSent: false},
+		{name: "case_insensitive", notes: "Internal only [SKIP-Teams]", wantSent: false},
+		{name: "custom_marker", config: map[string]any{"skip_marker": "#quiet"}, notes: "#QUIET patch", wantSent: false},
+		{name: "default_marker_ignored_when_customized", config: map[string]any{"skip_marker": "#quiet"}, notes: "[skip-teams]", wantSent: true},
+		{name: "marker_in_title", config: map[string]any{"title_template": "Release {{version}} [skip-teams]"}, notes: "feat: add export", wantSent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{
+				"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: tt.notes},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+			if sent := len(bodies) > 0; sent != tt.wantSent {
+				t.Errorf("expected sent=%v, got %v (%q)", tt.wantSent, sent, resp.Message)
+			}
+			if !tt.wantSent && resp.Message != "Skipped via marker" {
+				t.Errorf("unexpected message %q", resp.Message)
+			}
+		})
+	}
+}
+
+func TestSkipEmptyRelease(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		skip        bool
+		releaseCtx  plugin.ReleaseContext
+		wantMessage string
+	}{
+		{
+			name:        "empty_release_skipped",
+			skip:        true,
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0.1", Changes: &plugin.CategorizedChanges{}},
+			wantMessage: "Empty release skipped",
+		},
+		{
+			name:        "nil_changes_skipped",
+			skip:        true,
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0.1", ReleaseNotes: "  \n"},
+			wantMessage: "Empty release skipped",
+		},
+		{
+			name:        "empty_release_sent_when_disabled",
+			skip:        false,
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0.1"},
+			wantMessage: "Would send Teams success notification",
+		},
+		{
+			name: "release_with_changes_sent",
+			skip: true,
+			releaseCtx: plugin.ReleaseContext{
+				Version: "1.0.1",
+				Changes: &plugin.CategorizedChanges{Docs: []plugin.ConventionalCommit{{Description: "docs"}}},
+			},
+			wantMessage: "Would send Teams success notification",
+		},
+		{
+			name:        "release_with_notes_sent",
+			skip:        true,
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0.1", ReleaseNotes: "Manual bump"},
+			wantMessage: "Would send Teams success notification",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &TeamsPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"webhook_url":        "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"skip_empty_release": tt.skip,
+				},
+				Context: tt.releaseCtx,
+				DryRun:  true,
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+			}
+			if resp.Message != tt.wantMessage {
+				t.Errorf("expected message %q, got %q", tt.wantMessage, resp.Message)
+			}
+		})
+	}
+}
+
+func TestImportance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		webhook    string
+		importance string
+		wantStyle  string
+		wantText   string
+	}{
+		{
+			name:       "urgent_on_workflows",
+			webhook:    "https://prod-00.logic.azure.com:443/workflows/abc/triggers/manual/paths/invoke",
+			importance: "urgent",
+			wantStyle:  "attention",
+			wantText:   "Urgent",
+		},
+		{
+			name:       "high_on_connector",
+			webhook:    "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			importance: "high",
+			wantStyle:  "warning",
+			wantText:   "High importance",
+		},
+		{
+			name:       "normal_has_no_banner",
+			webhook:    "https://prod-00.logic.azure.com:443/workflows/abc/triggers/manual/paths/invoke",
+			importance: "normal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookOnError,
+				Config: map[string]any{
+					"webhook_url": tt.webhook,
+					"importance":  tt.importance,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+			}
+
+			first := decodeCard(t, bodies[0]).Body[0]
+			if tt.wantText == "" {
+				if first.Type != "TextBlock" || first.Text != "Release 1.0.0 Failed" {
+					t.Errorf("expected the title first, got %+v", first)
+				}
+				return
+			}
+			if first.Type != "Container" || first.Style != tt.wantStyle || len(first.Items) != 1 || first.Items[0].Text != tt.wantText {
+				t.Errorf("expected a %s banner %q first, got %+v", tt.wantStyle, tt.wantText, first)
+			}
+		})
+	}
+}
+
+func TestExpireAfterSeconds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		webhook  string
+		timezone string
+		want     string
+	}{
+		{
+			name:    "workflows",
+			webhook: "https://prod-00.logic.azure.com:443/workflows/abc/triggers/manual/paths/invoke",
+			want:    "Expires {{DATE(2024-05-01T12:05:00Z, SHORT)}} {{TIME(2024-05-01T12:05:00Z)}}",
+		},
+		{
+			name:     "connector_in_timezone",
+			webhook:  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			timezone: "Europe/Berlin",
+			want:     "Expires {{DATE(2024-05-01T14:05:00+02:00, SHORT)}} {{TIME(2024-05-01T14:05:00+02:00)}}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies [][]byte
+			p := &TeamsPlugin{
+				httpClient: recordingClient(&bodies),
+				now:        func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) },
+			}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"webhook_url":          tt.webhook,
+					"expire_after_seconds": 300,
+					"timezone":             tt.timezone,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+			}
+
+			body := decodeCard(t, bodies[0]).Body
+			if last := body[len(body)-1]; last.Text != tt.want {
+				t.Errorf("expected expiry note %q, got %+v", tt.want, last)
+			}
+		})
+	}
+}
+
+func TestReleaseTypeBadge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		releaseType string
+		wantStyle   string
+		wantLabel   string
+	}{
+		{releaseType: "patch", wantStyle: "good", wantLabel: "Patch"},
+		{releaseType: "minor", wantStyle: "accent", wantLabel: "Minor"},
+		{releaseType: "major", wantStyle: "warning", wantLabel: "Major"},
+		{releaseType: "custom", wantStyle: "emphasis", wantLabel: "Custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.releaseType, func(t *testing.T) {
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			cfg := &Config{
+				ShowInfo:         true,
+				WebhookURL:       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				ReleaseTypeBadge: true,
+			}
+
+			_, err := p.sendSuccessNotification(context.Background(), cfg, plugin.ReleaseContext{
+				Version:     "1.0.0",
+				ReleaseType: tt.releaseType,
+			}, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			info := decodeCard(t, bodies[0]).Body[1]
+			badge := info.Columns[1].Items[1]
+			if badge.Type != "Container" || badge.Style != tt.wantStyle {
+				t.Errorf("expected %q container badge, got %+v", tt.wantStyle, badge)
+			}
+			if len(badge.Items) != 1 || badge.Items[0].Text != tt.wantLabel {
+				t.Errorf("expected badge label %q, got %+v", tt.wantLabel, badge.Items)
+			}
+			if info.Columns[0].Items[1].Text != "Type:" {
+				t.Errorf("expected badge on the Type row, got label %q", info.Columns[0].Items[1].Text)
+			}
+		})
+	}
+}
+
+func TestFormatReleaseType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		releaseType string
+		want        string
+	}{
+		{name: "empty", releaseType: "", want: "Unknown"},
+		{name: "whitespace", releaseType: "   \t", want: "Unknown"},
+		{name: "mixed case", releaseType: "MiNoR", want: "Minor"},
+		{name: "padded", releaseType: "  major\n", want: "Major"},
+		{name: "lowercase", releaseType: "patch", want: "Patch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := formatReleaseType(tt.releaseType); got != tt.want {
+				t.Errorf("formatReleaseType(%q) = %q, want %q", tt.releaseType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseTypeRowNormalized(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	cfg := &Config{
+		ShowInfo:         true,
+		WebhookURL:       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		ReleaseTypeBadge: true,
+	}
+
+	_, err := p.sendSuccessNotification(context.Background(), cfg, plugin.ReleaseContext{
+		Version:     "1.0.0",
+		ReleaseType: " MAJOR ",
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	badge := decodeCard(t, bodies[0]).Body[1].Columns[1].Items[1]
+	if badge.Style != "warning" || badge.Items[0].Text != "Major" {
+		t.Errorf("expected normalized major badge, got %+v", badge)
+	}
+}
+
+func TestShowCardDetails(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	cfg := &Config{
+		ShowSummary:      true,
+		ShowInfo:         true,
+		ShowActions:      true,
+		WebhookURL:       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		IncludeChangelog: true,
+		ShowCardDetails:  true,
+	}
+
+	_, err := p.sendSuccessNotification(context.Background(), cfg, plugin.ReleaseContext{
+		Version:       "1.0.0",
+		RepositoryURL: "https://github.com/owner/repo",
+		TagName:       "v1.0.0",
+		ReleaseNotes:  "Some notes",
+		Changes:       &plugin.CategorizedChanges{},
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var msg struct {
+		Attachments []struct {
+			Content struct {
+				Body    []map[string]any `json:"body"`
+				Actions []map[string]any `json:"actions"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(bodies[0], &msg); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	content := msg.Attachments[0].Content
+
+	if len(content.Body) != 2 {
+		t.Errorf("expected only header and info in the main body, got %d elements", len(content.Body))
+	}
+	if len(content.Actions) != 2 {
+		t.Fatalf("expected ShowCard and OpenUrl actions, got %d", len(content.Actions))
+	}
+
+	action := content.Actions[0]
+	if action["type"] != "Action.ShowCard" || action["title"] != "Show details" {
+		t.Errorf("unexpected first action: %v", action)
+	}
+	card, ok := action["card"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected nested card object, got %v", action["card"])
+	}
+	if card["type"] != "AdaptiveCard" || card["version"] != AdaptiveCardVersion {
+		t.Errorf("unexpected nested card header: type=%v version=%v", card["type"], card["version"])
+	}
+	nested, _ := card["body"].([]any)
+	if len(nested) != 2 {
+		t.Fatalf("expected changes and changelog in the nested card, got %d elements", len(nested))
+	}
+	if text := nested[1].(map[string]any)["text"]; text != "Some notes" {
+		t.Errorf("expected changelog in nested card, got %v", text)
+	}
+	if _, ok := content.Actions[1]["card"]; ok {
+		t.Error("OpenUrl action should not carry a card")
+	}
+}
+
+func TestShowCardDetailsWithoutDetails(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	n := p.buildSuccessNotification(&Config{ShowCardDetails: true}, plugin.ReleaseContext{Version: "1.0.0"})
+	for _, action := range n.actions {
+		if action.Type == "Action.ShowCard" {
+			t.Error("expected no ShowCard action when there are no details")
+		}
+	}
+}
+
+func TestCardVersionAtLeast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "1.2", want: true},
+		{version: "1.5", want: true},
+		{version: "2.0", want: true},
+		{version: "1.1", want: false},
+		{version: "1.0", want: false},
+		{version: "bogus", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := cardVersionAtLeast(tt.version, 1, 2); got != tt.want {
+			t.Errorf("cardVersionAtLeast(%q, 1, 2) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestHeaderIcons(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	cfg := &Config{SuccessIcon: "🚀", ErrorIcon: "🔥"}
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0"}
+
+	if got := p.buildSuccessNotification(cfg, releaseCtx).body[0].Text; got != "🚀 Release 1.0.0" {
+		t.Errorf("unexpected success header: %q", got)
+	}
+	if got := p.buildErrorNotification(cfg, releaseCtx).body[0].Text; got != "🔥 Release 1.0.0 Failed" {
+		t.Errorf("unexpected error header: %q", got)
+	}
+
+	if got := p.buildSuccessNotification(&Config{}, releaseCtx).body[0].Text; got != "Release 1.0.0" {
+		t.Errorf("expected no icon by default, got %q", got)
+	}
+}
+
+func TestValidateIconLength(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url":  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"success_icon": "✅",
+		"error_icon":   strings.Repeat("x", MaxIconLength+1),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid {
+		t.Fatal("expected overlong error_icon to be rejected")
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Field != "error_icon" {
+		t.Errorf("expected a single error_icon error, got %+v", resp.Errors)
+	}
+}
+
+func TestWebhookRouting(t *testing.T) {
+	t.Parallel()
+
+	const (
+		primary = "https://example.webhook.office.com/webhookb2/primary/IncomingWebhook/1/2"
+		success = "https://example.webhook.office.com/webhookb2/success/IncomingWebhook/3/4"
+		failure = "https://example.webhook.office.com/webhookb2/error/IncomingWebhook/5/6"
+	)
+
+	tests := []struct {
+		name    string
+		hook    plugin.Hook
+		config  map[string]any
+		wantURL string
+	}{
+		{
+			name:    "success_to_success_webhook",
+			hook:    plugin.HookPostPublish,
+			config:  map[string]any{"webhook_url": primary, "webhook_url_success": success, "webhook_url_error": failure},
+			wantURL: success,
+		},
+		{
+			name:    "error_to_error_webhook",
+			hook:    plugin.HookOnError,
+			config:  map[string]any{"webhook_url": primary, "webhook_url_success": success, "webhook_url_error": failure},
+			wantURL: failure,
+		},
+		{
+			name:    "success_falls_back",
+			hook:    plugin.HookPostPublish,
+			config:  map[string]any{"webhook_url": primary, "webhook_url_error": failure},
+			wantURL: primary,
+		},
+		{
+			name:    "error_falls_back",
+			hook:    plugin.HookOnError,
+			config:  map[string]any{"webhook_url": primary, "webhook_url_success": success},
+			wantURL: primary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var urls []string
+			p := &TeamsPlugin{httpClient: &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					urls = append(urls, req.URL.String())
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+				},
+			}}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    tt.hook,
+				Config:  tt.config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+			if len(urls) != 1 || urls[0] != tt.wantURL {
+				t.Errorf("expected delivery to %s, got %v", tt.wantURL, urls)
+			}
+		})
+	}
+}
+
+func TestValidateRoutedWebhooks(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url":         "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"webhook_url_success": "https://example.webhook.office.com/webhookb2/456/IncomingWebhook/1/2",
+		"webhook_url_error":   "http://evil.example.com/hook",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || len(resp.Errors) != 1 || resp.Errors[0].Field != "webhook_url_error" {
+		t.Errorf("expected a single webhook_url_error error, got %+v", resp.Errors)
+	}
+}
+
+func TestEmptyChangelogText(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0"}
+
+	t.Run("placeholder", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{IncludeChangelog: true, ShowInfo: true, EmptyChangelogText: "No release notes provided"}
+		body := p.buildSuccessNotification(cfg, releaseCtx).body
+		last := body[len(body)-1]
+		if last.Text != "No release notes provided" || !last.IsSubtle {
+			t.Errorf("expected subtle placeholder, got %+v", last)
+		}
+	})
+
+	t.Run("omitted_by_default", func(t *testing.T) {
+		t.Parallel()
+		body := p.buildSuccessNotification(&Config{IncludeChangelog: true, ShowInfo: true}, releaseCtx).body
+		if len(body) != 2 {
+			t.Errorf("expected only header and info, got %d elements", len(body))
+		}
+	})
+
+	t.Run("notes_take_precedence", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{IncludeChangelog: true, ShowInfo: true, EmptyChangelogText: "No release notes provided"}
+		body := p.buildSuccessNotification(cfg, plugin.ReleaseContext{Version: "1.0.0", ReleaseNotes: "Fixed it"}).body
+		if last := body[len(body)-1]; last.Text != "Fixed it" {
+			t.Errorf("expected release notes, got %q", last.Text)
+		}
+	})
+
+	t.Run("changelog_disabled", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{ShowInfo: true, EmptyChangelogText: "No release notes provided"}
+		body := p.buildSuccessNotification(cfg, releaseCtx).body
+		if len(body) != 2 {
+			t.Errorf("expected no placeholder when include_changelog is off, got %d elements", len(body))
+		}
+	})
+}
+
+func TestMaxActions(t *testing.T) {
+	t.Parallel()
+
+	actions := make([]AdaptiveAction, 10)
+	for i := range actions {
+		actions[i] = AdaptiveAction{Type: "Action.OpenUrl", Title: fmt.Sprintf("Link %d", i), URL: "https://example.com"}
+	}
+	n := notification{
+		status:  StatusSuccess,
+		body:    []AdaptiveElement{{Type: "TextBlock", Text: "Release 1.0.0"}},
+		actions: actions,
+	}
+
+	p := &TeamsPlugin{}
+	msg := p.buildNotificationMessage(&Config{MaxActions: 6}, n, nil)
+	card := msg.Attachments[0].Content
+
+	if len(card.Actions) != 6 {
+		t.Fatalf("expected 6 actions, got %d", len(card.Actions))
+	}
+	if card.Actions[0].Title != "Link 0" || card.Actions[5].Title != "Link 5" {
+		t.Errorf("expected the first actions to be kept, got %q..%q", card.Actions[0].Title, card.Actions[5].Title)
+	}
+	if last := card.Body[len(card.Body)-1]; last.Text != "and 4 more actions" {
+		t.Errorf("expected overflow note, got %q", last.Text)
+	}
+	if len(n.body) != 1 {
+		t.Error("capping must not modify the notification body")
+	}
+
+	uncapped := p.buildNotificationMessage(&Config{}, n, nil).Attachments[0].Content
+	if len(uncapped.Actions) != 10 {
+		t.Errorf("expected no cap when max_actions is 0, got %d actions", len(uncapped.Actions))
+	}
+}
+
+func TestActionsPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		position string
+		wantTop  bool
+	}{
+		{name: "default", position: "", wantTop: false},
+		{name: "bottom", position: "bottom", wantTop: false},
+		{name: "top", position: "top", wantTop: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{
+				"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			}
+			if tt.position != "" {
+				config["actions_position"] = tt.position
+			}
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:   plugin.HookPostPublish,
+				Config: config,
+				Context: plugin.ReleaseContext{
+					Version:       "1.0.0",
+					TagName:       "v1.0.0",
+					RepositoryURL: "https://github.com/relicta-tech/relicta",
+				},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			card := decodeCard(t, bodies[0])
+			first := card.Body[0]
+			if !tt.wantTop {
+				if first.Type == "ActionSet" {
+					t.Error("expected no inline ActionSet")
+				}
+				if len(card.Actions) == 0 || card.Actions[len(card.Actions)-1].Title != "View Release" {
+					t.Errorf("expected View Release in card actions, got %+v", card.Actions)
+				}
+				return
+			}
+			if len(card.Actions) != 0 {
+				t.Errorf("expected no card-level actions, got %+v", card.Actions)
+			}
+			if first.Type != "ActionSet" || len(first.Actions) == 0 || first.Actions[len(first.Actions)-1].Title != "View Release" {
+				t.Errorf("expected an ActionSet with View Release first in the body, got %+v", first)
+			}
+		})
+	}
+}
+
+func TestValidateActionsPosition(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for position, wantValid := range map[string]bool{"top": true, "Bottom": true, "middle": false} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":      "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"actions_position": position,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("actions_position %q: expected Valid=%v, got %+v", position, wantValid, resp.Errors)
+		}
+	}
+}
+
+func TestMinimumCardBody(t *testing.T) {
+	t.Parallel()
+
+	t.Run("everything_disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var bodies [][]byte
+		p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook: plugin.HookPostPublish,
+			Config: map[string]any{
+				"webhook_url":       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				"title_template":    "{{ .PreviousVersion }}",
+				"include_changelog": false,
+				"show_deprecations": false,
+			},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %+v", err, resp)
+		}
+
+		card := decodeCard(t, bodies[0])
+		if card.Body[0].Text != "Release 1.0.0" {
+			t.Errorf("expected a blank title to fall back to the default, got %q", card.Body[0].Text)
+		}
+		if !strings.Contains(string(bodies[0]), `"1.0.0"`) {
+			t.Errorf("expected the version fact, got %s", bodies[0])
+		}
+	})
+
+	t.Run("empty_body", func(t *testing.T) {
+		t.Parallel()
+
+		hidden := false
+		p := &TeamsPlugin{}
+		for status, want := range map[string]string{
+			StatusSuccess:  "Release 2.0.0 published",
+			StatusError:    "Release 2.0.0 failed",
+			StatusApproval: "Release 2.0.0 approved",
+		} {
+			n := notification{
+				status:  status,
+				version: "2.0.0",
+				body:    []AdaptiveElement{{Type: "TextBlock", Text: "{}", IsVisible: &hidden}},
+			}
+			card := p.buildNotificationMessage(&Config{}, n, nil).Attachments[0].Content
+			if card.Body[0].Text != want {
+				t.Errorf("%s: expected minimal block %q, got %+v", status, want, card.Body)
+			}
+			if len(card.Body) != 2 {
+				t.Errorf("%s: expected the hidden element to be kept, got %d elements", status, len(card.Body))
+			}
+		}
+	})
+}
+
+func TestPrettyPayload(t *testing.T) {
+	t.Parallel()
+
+	for _, pretty := range []bool{false, true} {
+		var bodies [][]byte
+		p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook: plugin.HookPostPublish,
+			Config: map[string]any{
+				"webhook_url":    "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				"pretty_payload": pretty,
+			},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("unexpected failure: %v %+v", err, resp)
+		}
+
+		indented := bytes.HasPrefix(bodies[0], []byte("{\n  \"type\": \"message\""))
+		if indented != pretty {
+			t.Errorf("pretty_payload=%v: expected indented=%v, got %s", pretty, pretty, bodies[0][:min(len(bodies[0]), 40)])
+		}
+		if !json.Valid(bodies[0]) {
+			t.Errorf("pretty_payload=%v: expected valid JSON", pretty)
+		}
+	}
+}
+
+func TestPrettyPayloadKeepsSizeGuard(t *testing.T) {
+	t.Parallel()
+
+	// Many small elements add far more indentation than content
+	body := make([]AdaptiveElement, 0, 700)
+	for range cap(body) {
+		body = append(body, AdaptiveElement{Type: "TextBlock", Text: "x"})
+	}
+	msg := (&TeamsPlugin{}).buildTeamsMessage(body, nil, nil, "")
+
+	compact, err := marshalPayload(msg, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(compact) > MaxPayloadBytes {
+		t.Fatalf("test message must fit compact, got %d bytes", len(compact))
+	}
+	pretty, err := marshalPayload(msg, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(pretty, compact) {
+		t.Errorf("expected a compact payload when indenting would exceed %d bytes, got %d bytes", MaxPayloadBytes, len(pretty))
+	}
+}
+
+func TestRequestInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var received []*http.Request
+	var bodies [][]byte
+	p := &TeamsPlugin{
+		httpClient: &MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				received = append(received, req)
+				bodies = append(bodies, body)
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+			},
+		},
+		RequestInterceptor: func(req *http.Request) error {
+			// Reading the body must not empty what is sent
+			body, err := io.ReadAll(req.Body)
+			if err != nil || !bytes.Contains(body, []byte("Release 1.0.0")) {
+				return fmt.Errorf("unexpected body %q: %v", body, err)
+			}
+			req.Header.Set("X-Trace-ID", "trace-123")
+			return nil
+		},
+	}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+	if len(received) != 1 || received[0].Header.Get("X-Trace-ID") != "trace-123" {
+		t.Fatalf("expected the interceptor's header on the request, got %d requests", len(received))
+	}
+	if card := decodeCard(t, bodies[0]); card.Body[0].Text != "Release 1.0.0" {
+		t.Errorf("expected the full card to be sent, got %q", bodies[0])
+	}
+}
+
+func TestRequestInterceptorAborts(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	errBlocked := errors.New("blocked by policy")
+	attempts := 0
+	p := &TeamsPlugin{
+		httpClient: recordingClient(&bodies),
+		RequestInterceptor: func(*http.Request) error {
+			attempts++
+			return errBlocked
+		},
+	}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"max_retries": 3,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "request aborted by interceptor: blocked by policy") {
+		t.Errorf("expected the interceptor's error, got %+v", resp)
+	}
+	if len(bodies) != 0 {
+		t.Errorf("expected nothing to be sent, got %d requests", len(bodies))
+	}
+	if attempts != 1 {
+		t.Errorf("expected an aborted send not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestTitleMaxChars(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0"}
+
+	tests := []struct {
+		name     string
+		template string
+		maxChars int
+		want     string
+	}{
+		{name: "short", template: "Release {{version}}", maxChars: 150, want: "Release 1.0.0"},
+		{name: "exact", template: "Release {{version}}", maxChars: 13, want: "Release 1.0.0"},
+		{name: "over_long", template: "Release {{version}} " + strings.Repeat("x", 200), maxChars: 150, want: "Release 1.0.0 " + strings.Repeat("x", 135) + "…"},
+		{name: "rune_boundary", template: "Veröffentlichung für Österreich", maxChars: 20, want: "Veröffentlichung fü…"},
+		{name: "trailing_space_trimmed", template: "Release {{version}} is out", maxChars: 15, want: "Release 1.0.0…"},
+		{name: "unlimited", template: "Release {{version}}", maxChars: 0, want: "Release 1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := p.buildTitle(tt.template, tt.maxChars, releaseCtx)
+			if got != tt.want {
+				t.Errorf("buildTitle() = %q, want %q", got, tt.want)
+			}
+			if tt.maxChars > 0 && utf8.RuneCountInString(got) > tt.maxChars {
+				t.Errorf("expected at most %d characters, got %d", tt.maxChars, utf8.RuneCountInString(got))
+			}
+		})
+	}
+}
+
+func TestTitleMaxCharsRendered(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":     "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"title_template":  "Release {{version}}: " + strings.Repeat("long commit message ", 20),
+			"title_max_chars": 30,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+	if title := decodeCard(t, bodies[0]).Body[0].Text; title != "Release 1.0.0: long commit me…" {
+		t.Errorf("unexpected title %q", title)
+	}
+}
+
+func TestDryRunMarshalsCard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		marshalFunc func(msg TeamsMessage, pretty bool) ([]byte, error)
+		wantError   string
+	}{
+		{name: "valid_card"},
+		{
+			name: "unmarshalable_element",
+			marshalFunc: func(msg TeamsMessage, pretty bool) ([]byte, error) {
+				return json.Marshal(struct {
+					TeamsMessage
+					Extra chan int `json:"extra"`
+				}{msg, make(chan int)})
+			},
+			wantError: "dry run: failed to marshal message: json: unsupported type: chan int",
+		},
+		{
+			name: "oversized_payload",
+			marshalFunc: func(TeamsMessage, bool) ([]byte, error) {
+				return make([]byte, MaxPayloadBytes+1), nil
+			},
+			wantError: fmt.Sprintf("dry run: message is %d bytes, over the Teams limit of %d bytes", MaxPayloadBytes+1, MaxPayloadBytes),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &TeamsPlugin{marshalFunc: tt.marshalFunc}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+				DryRun:  true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != (tt.wantError == "") {
+				t.Fatalf("expected Success=%v, got %+v", tt.wantError == "", resp)
+			}
+			if resp.Error != tt.wantError {
+				t.Errorf("expected error %q, got %q", tt.wantError, resp.Error)
+			}
+		})
+	}
+}
+
+func TestCardLanguage(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	n := p.buildSuccessNotification(&Config{}, plugin.ReleaseContext{Version: "1.0.0"})
+
+	tests := []struct {
+		name     string
+		language string
+		wantLang any
+	}{
+		{name: "configured", language: "de-DE", wantLang: "de-DE"},
+		{name: "omitted_by_default", language: "", wantLang: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			payload, err := json.Marshal(p.buildNotificationMessage(&Config{Language: tt.language}, n, nil))
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			var msg struct {
+				Attachments []struct {
+					Content map[string]any `json:"content"`
+				} `json:"attachments"`
+			}
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+			if got := msg.Attachments[0].Content["lang"]; got != tt.wantLang {
+				t.Errorf("expected lang %v, got %v", tt.wantLang, got)
+			}
+		})
+	}
+}
+
+func TestCardRTL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantRTL any
+	}{
+		{name: "enabled", config: map[string]any{"rtl": true, "language": "ar"}, wantRTL: true},
+		{name: "omitted_by_default", config: map[string]any{}, wantRTL: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  tt.config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			var msg struct {
+				Attachments []struct {
+					Content map[string]any `json:"content"`
+				} `json:"attachments"`
+			}
+			if err := json.Unmarshal(bodies[0], &msg); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+			if got := msg.Attachments[0].Content["rtl"]; got != tt.wantRTL {
+				t.Errorf("expected rtl %v, got %v", tt.wantRTL, got)
+			}
+		})
+	}
+}
+
+func TestValidateRTLLanguageWarning(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for _, tt := range []struct {
+		config      map[string]any
+		wantWarning bool
+	}{
+		{config: map[string]any{"language": "he-IL"}, wantWarning: true},
+		{config: map[string]any{"language": "he-IL", "rtl": true}, wantWarning: false},
+		{config: map[string]any{"language": "de-DE"}, wantWarning: false},
+	} {
+		tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+		resp, err := p.Validate(context.Background(), tt.config)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Valid {
+			t.Errorf("%v: expected a valid config, got %+v", tt.config, resp.Errors)
+		}
+		warned := false
+		for _, e := range resp.Errors {
+			warned = warned || (e.Field == "rtl" && isWarning(e))
+		}
+		if warned != tt.wantWarning {
+			t.Errorf("%v: expected rtl warning=%v, got %+v", tt.config, tt.wantWarning, resp.Errors)
+		}
+	}
+}
+
+func TestValidateLanguage(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for lang, wantValid := range map[string]bool{"en": true, "pt-BR": true, "en US": false, "<script>": false} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"language":    lang,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("language %q: expected Valid=%v, got %+v", lang, wantValid, resp.Errors)
+		}
+	}
+}
+
+func TestExecuteDisabled(t *testing.T) {
+	hooks := []plugin.Hook{
+		plugin.HookPostApprove,
+		plugin.HookPostPublish,
+		plugin.HookOnSuccess,
+		plugin.HookOnError,
+		plugin.HookPreInit,
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		env    string
+	}{
+		{name: "config", config: map[string]any{"enabled": false}},
+		{name: "env", config: map[string]any{}, env: "true"},
+		{name: "env_overrides_config", config: map[string]any{"enabled": true}, env: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvDisabled, tt.env)
+
+			p := &TeamsPlugin{httpClient: &MockHTTPClient{
+				DoFunc: func(*http.Request) (*http.Response, error) {
+					t.Error("no request expected while disabled")
+					return nil, errors.New("unexpected request")
+				},
+			}}
+			tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+			tt.config["notify_on_approval"] = true
+
+			for _, hook := range hooks {
+				resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+					Hook:    hook,
+					Config:  tt.config,
+					Context: plugin.ReleaseContext{Version: "1.0.0"},
+				})
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", hook, err)
+				}
+				if !resp.Success || resp.Message != "Teams plugin disabled" {
+					t.Errorf("%s: expected disabled no-op, got %+v", hook, resp)
+				}
+			}
+		})
+	}
+}
+
+func TestNotifyDefaultsFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]any
+		envSuccess  string
+		envError    string
+		wantSuccess bool
+		wantError   bool
+	}{
+		{name: "unset", config: map[string]any{}, wantSuccess: true, wantError: true},
+		{name: "errors_only", config: map[string]any{}, envSuccess: "false", envError: "true", wantSuccess: false, wantError: true},
+		{name: "numeric", config: map[string]any{}, envSuccess: "0", envError: "0", wantSuccess: false, wantError: false},
+		{name: "invalid_env_ignored", config: map[string]any{}, envSuccess: "sometimes", wantSuccess: true, wantError: true},
+		{
+			name:        "config_wins",
+			config:      map[string]any{"notify_on_success": true, "notify_on_error": false},
+			envSuccess:  "false",
+			envError:    "true",
+			wantSuccess: true,
+			wantError:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvNotifyOnSuccess, tt.envSuccess)
+			t.Setenv(EnvNotifyOnError, tt.envError)
+
+			cfg := (&TeamsPlugin{}).parseConfig(tt.config)
+			if cfg.NotifyOnSuccess != tt.wantSuccess {
+				t.Errorf("NotifyOnSuccess = %v, want %v", cfg.NotifyOnSuccess, tt.wantSuccess)
+			}
+			if cfg.NotifyOnError != tt.wantError {
+				t.Errorf("NotifyOnError = %v, want %v", cfg.NotifyOnError, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestNotifyOnSuccessEnvSkipsSuccess(t *testing.T) {
+	t.Setenv(EnvNotifyOnSuccess, "false")
+
+	p := &TeamsPlugin{httpClient: &MockHTTPClient{
+		DoFunc: func(*http.Request) (*http.Response, error) {
+			t.Error("no request expected")
+			return nil, errors.New("unexpected request")
+		},
+	}}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+}
+
+func TestQuietUnhandled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		quiet       bool
+		wantMessage string
+	}{
+		{name: "verbose_by_default", quiet: false, wantMessage: "Hook pre-plan not handled"},
+		{name: "quiet", quiet: true, wantMessage: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := &captureLogger{}
+			p := &TeamsPlugin{Logger: logger}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPrePlan,
+				Config: map[string]any{
+					"webhook_url":     "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"quiet_unhandled": tt.quiet,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success || resp.Message != tt.wantMessage {
+				t.Errorf("expected success with message %q, got %+v", tt.wantMessage, resp)
+			}
+
+			_, logged := logger.find("debug", "hook not handled")
+			if logged != tt.quiet {
+				t.Errorf("expected debug log=%v, got %v", tt.quiet, logged)
+			}
+		})
+	}
+}
+
+func TestBackgroundImage(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	n := p.buildSuccessNotification(&Config{}, plugin.ReleaseContext{Version: "1.0.0"})
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "configured", url: "https://cdn.example.com/brand.png"},
+		{name: "absent_by_default", url: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			payload, err := json.Marshal(p.buildNotificationMessage(&Config{BackgroundImageURL: tt.url}, n, nil))
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			var msg struct {
+				Attachments []struct {
+					Content map[string]any `json:"content"`
+				} `json:"attachments"`
+			}
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+
+			got, present := msg.Attachments[0].Content["backgroundImage"]
+			if tt.url == "" {
+				if present {
+					t.Errorf("expected no backgroundImage, got %v", got)
+				}
+				return
+			}
+			if got != tt.url {
+				t.Errorf("expected backgroundImage %q, got %v", tt.url, got)
+			}
+		})
+	}
+}
+
+func TestValidateBackgroundImageURL(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url":          "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"background_image_url": "http://cdn.example.com/brand.png",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid || resp.Errors[0].Field != "background_image_url" {
+		t.Errorf("expected background_image_url error, got %+v", resp.Errors)
+	}
+}
+
+func TestFailedJobLogsAction(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version:         "1.0.0",
+		Branch:          "main",
+		CommitSHA:       "abc123",
+		RepositoryOwner: "acme",
+		RepositoryName:  "api",
+		ReleaseType:     "minor",
+		Environment:     map[string]string{EnvLogsURL: "https://ci.example.com/runs/42"},
+	}
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		releaseCtx plugin.ReleaseContext
+		wantURL    string
+	}{
+		{
+			name:       "template",
+			cfg:        Config{LogsURLTemplate: "https://ci.example.com/{{repository}}/builds/{{commit}}"},
+			releaseCtx: releaseCtx,
+			wantURL:    "https://ci.example.com/acme/api/builds/abc123",
+		},
+		{
+			name:       "template_fields",
+			cfg:        Config{LogsURLTemplate: "https://ci.example.com/{{branch}}/{{release_type}}/{{ .Tag | default .Version }}"},
+			releaseCtx: releaseCtx,
+			wantURL:    "https://ci.example.com/main/minor/1.0.0",
+		},
+		{
+			name:       "template_error_omits_action",
+			cfg:        Config{LogsURLTemplate: "https://ci.example.com/{{ .Missing }}"},
+			releaseCtx: releaseCtx,
+		},
+		{
+			name:       "release_environment",
+			releaseCtx: releaseCtx,
+			wantURL:    "https://ci.example.com/runs/42",
+		},
+		{
+			name:       "none",
+			releaseCtx: plugin.ReleaseContext{Version: "1.0.0"},
+		},
+		{
+			name:       "non_https_ignored",
+			releaseCtx: plugin.ReleaseContext{Environment: map[string]string{EnvLogsURL: "javascript:alert(1)"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := tt.cfg
+			cfg.ShowActions = true
+			n := (&TeamsPlugin{}).buildErrorNotification(&cfg, tt.releaseCtx)
+			if tt.wantURL == "" {
+				if len(n.actions) != 0 {
+					t.Errorf("expected no actions, got %+v", n.actions)
+				}
+				return
+			}
+			if len(n.actions) != 1 {
+				t.Fatalf("expected one action, got %+v", n.actions)
+			}
+			if n.actions[0].Title != "View Failed Job" || n.actions[0].URL != tt.wantURL {
+				t.Errorf("unexpected action: %+v", n.actions[0])
+			}
+		})
+	}
+}
+
+func TestValidateLogsURLTemplate(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for template, wantValid := range map[string]bool{
+		"https://ci.example.com/{{repository}}/builds/{{commit}}": true,
+		"http://ci.example.com/{{commit}}":                        false,
+		"{{repository_url}}/actions":                              true,
+	} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"logs_url_template": template,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("template %q: expected Valid=%v, got %+v", template, wantValid, resp.Errors)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// semverPattern matches a semantic version, capturing the prerelease part.
+// A leading "v" is accepted, as in tag names.
+var semverPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// prereleaseLabels maps prerelease identifiers to the labels shown in titles.
+var prereleaseLabels = map[string]string{
+	"rc":    "Release Candidate",
+	"beta":  "Beta",
+	"alpha": "Alpha",
+}
+
+// prereleaseLabel returns the human label for a version's prerelease
+// identifier, e.g. "Release Candidate" for "1.2.3-rc.1" or "1.2.3-RC2".
+// Unknown identifiers are labeled "Pre-release"; stable versions and
+// versions that aren't semver yield "".
+func prereleaseLabel(version string) string {
+	match := semverPattern.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil || match[1] == "" {
+		return ""
+	}
+	identifier, _, _ := strings.Cut(match[1], ".")
+	identifier = strings.TrimRight(strings.ToLower(identifier), "0123456789")
+	if label, ok := prereleaseLabels[identifier]; ok {
+		return label
+	}
+	return "Pre-release"
+}
+
+// decoratePrerelease appends the prerelease label of version to title.
+func decoratePrerelease(title, version string) string {
+	if label := prereleaseLabel(version); label != "" {
+		return title + " (" + label + ")"
+	}
+	return title
+}
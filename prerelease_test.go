@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPrereleaseLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "1.2.3-rc.1", want: "Release Candidate"},
+		{version: "v1.2.3-RC2", want: "Release Candidate"},
+		{version: "1.2.3-beta", want: "Beta"},
+		{version: "1.2.3-beta.2+build.5", want: "Beta"},
+		{version: "2.0.0-alpha.1", want: "Alpha"},
+		{version: "2.0.0-preview.3", want: "Pre-release"},
+		{version: "1.2.3", want: ""},
+		{version: "v1.2.3+build.5", want: ""},
+		{version: "nightly", want: ""},
+		{version: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+			if got := prereleaseLabel(tt.version); got != tt.want {
+				t.Errorf("prereleaseLabel(%q) = %q, want %q", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoratePrereleaseTitle(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	tests := []struct {
+		name     string
+		decorate bool
+		version  string
+		want     string
+	}{
+		{name: "prerelease", decorate: true, version: "1.2.3-rc.1", want: "Release 1.2.3-rc.1 (Release Candidate)"},
+		{name: "stable", decorate: true, version: "1.2.3", want: "Release 1.2.3"},
+		{name: "disabled", decorate: false, version: "1.2.3-rc.1", want: "Release 1.2.3-rc.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{DecoratePrerelease: tt.decorate}
+			n := p.buildSuccessNotification(cfg, plugin.ReleaseContext{Version: tt.version})
+			if got := n.body[0].Text; got != tt.want {
+				t.Errorf("expected title %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// previousTag returns the tag of the previous version, following the "v"
+// prefix convention of the current tag.
+func previousTag(releaseCtx plugin.ReleaseContext) string {
+	previous := releaseCtx.PreviousVersion
+	if strings.HasPrefix(releaseCtx.TagName, "v") && !strings.HasPrefix(previous, "v") {
+		return "v" + previous
+	}
+	return previous
+}
+
+// buildCompareURL returns the URL comparing the previous and current tags,
+// or "" when the repository or either tag is unknown.
+func buildCompareURL(releaseCtx plugin.ReleaseContext) string {
+	if releaseCtx.RepositoryURL == "" || releaseCtx.TagName == "" || releaseCtx.PreviousVersion == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/compare/%s...%s", repositoryWebURL(releaseCtx.RepositoryURL), previousTag(releaseCtx), releaseCtx.TagName)
+}
+
+// previousVersionText describes the upgrade, e.g. "Upgraded from 1.2.2 → 1.2.3",
+// linked to the compare view when one can be derived. Releases without a
+// previous version are an "Initial release".
+func previousVersionText(releaseCtx plugin.ReleaseContext) string {
+	previous := strings.TrimSpace(releaseCtx.PreviousVersion)
+	if previous == "" {
+		return "Initial release"
+	}
+	upgrade := fmt.Sprintf("%s → %s", previous, releaseCtx.Version)
+	if compareURL := buildCompareURL(releaseCtx); compareURL != "" {
+		upgrade = fmt.Sprintf("[%s](%s)", upgrade, compareURL)
+	}
+	return "Upgraded from " + upgrade
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPreviousVersionText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		releaseCtx plugin.ReleaseContext
+		want       string
+	}{
+		{
+			name: "upgrade_with_compare_link",
+			releaseCtx: plugin.ReleaseContext{
+				Version:         "1.2.3",
+				PreviousVersion: "1.2.2",
+				TagName:         "v1.2.3",
+				RepositoryURL:   "git@github.com:relicta-tech/relicta.git",
+			},
+			want: "Upgraded from [1.2.2 → 1.2.3](https://github.com/relicta-tech/relicta/compare/v1.2.2...v1.2.3)",
+		},
+		{
+			name: "unprefixed_tags",
+			releaseCtx: plugin.ReleaseContext{
+				Version:         "2.0.0",
+				PreviousVersion: "1.9.0",
+				TagName:         "2.0.0",
+				RepositoryURL:   "https://github.com/relicta-tech/relicta",
+			},
+			want: "Upgraded from [1.9.0 → 2.0.0](https://github.com/relicta-tech/relicta/compare/1.9.0...2.0.0)",
+		},
+		{
+			name:       "upgrade_without_repository",
+			releaseCtx: plugin.ReleaseContext{Version: "1.2.3", PreviousVersion: "1.2.2"},
+			want:       "Upgraded from 1.2.2 → 1.2.3",
+		},
+		{
+			name:       "initial_release",
+			releaseCtx: plugin.ReleaseContext{Version: "0.1.0", TagName: "v0.1.0", RepositoryURL: "https://github.com/relicta-tech/relicta"},
+			want:       "Initial release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := previousVersionText(tt.releaseCtx); got != tt.want {
+				t.Errorf("previousVersionText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShowPreviousVersion(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	releaseCtx := plugin.ReleaseContext{Version: "1.2.3", PreviousVersion: "1.2.2"}
+
+	n := p.buildSuccessNotification(&Config{ShowInfo: true, ShowPreviousVersion: true}, releaseCtx)
+	if got := infoFacts(n.body)["Upgrade:"]; got != "Upgraded from 1.2.2 → 1.2.3" {
+		t.Errorf("unexpected upgrade fact: %q", got)
+	}
+
+	n = p.buildSuccessNotification(&Config{ShowInfo: true, ShowPreviousVersion: true}, plugin.ReleaseContext{Version: "0.1.0"})
+	if got := infoFacts(n.body)["Upgrade:"]; got != "Initial release" {
+		t.Errorf("expected an initial release fact, got %q", got)
+	}
+
+	n = p.buildSuccessNotification(&Config{}, releaseCtx)
+	if _, ok := infoFacts(n.body)["Upgrade:"]; ok {
+		t.Error("expected no upgrade fact without show_previous_version")
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestReedSolomon(t *testing.T) {
+	t.Parallel()
+
+	// "HELLO WORLD" at version 1-M, from the QR code specification examples
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsGenerator(10)); !bytes.Equal(got, want) {
+		t.Errorf("rsRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestQRFormatAndVersionBits(t *testing.T) {
+	t.Parallel()
+
+	if got := qrFormatBits(0); got != 0b101010000010010 {
+		t.Errorf("qrFormatBits(0) = %015b", got)
+	}
+	if got := qrFormatBits(5); got != 0b100000011001110 {
+		t.Errorf("qrFormatBits(5) = %015b", got)
+	}
+	if got := qrVersionBits(7); got != 0b000111110010010100 {
+		t.Errorf("qrVersionBits(7) = %018b", got)
+	}
+}
+
+// readQR reads the data codewords back from a code: it finds the mask from
+// the format information, then unmasks and reads the modules in placement order.
+func readQR(t *testing.T, qr *qrCode, version int) []byte {
+	t.Helper()
+
+	format := 0
+	for i := 0; i <= 5; i++ {
+		if qr.modules[i][8] {
+			format |= 1 << i
+		}
+	}
+	for i, pos := range [][2]int{{7, 8}, {8, 8}, {8, 7}} {
+		if qr.modules[pos[0]][pos[1]] {
+			format |= 1 << (6 + i)
+		}
+	}
+	for i := 9; i < 15; i++ {
+		if qr.modules[8][14-i] {
+			format |= 1 << i
+		}
+	}
+	// The second copy must agree with the first
+	second := 0
+	for i := 0; i < 8; i++ {
+		if qr.modules[8][qr.size-1-i] {
+			second |= 1 << i
+		}
+	}
+	for i := 8; i < 15; i++ {
+		if qr.modules[qr.size-15+i][8] {
+			second |= 1 << i
+		}
+	}
+	if second != format {
+		t.Fatalf("format information copies differ: %015b and %015b", format, second)
+	}
+
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if qrFormatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("unrecognized format information %015b", format)
+	}
+
+	qr.applyMask(mask)
+	defer qr.applyMask(mask)
+	blocks := qrVersionsM[version-1]
+	total := blocks.dataCodewords() + blocks.ecPerBlock*(blocks.group1+blocks.group2)
+	codewords := make([]byte, total)
+	i := 0
+	for right := qr.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < qr.size; vert++ {
+			y := vert
+			if (right+1)&2 == 0 {
+				y = qr.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				if x := right - j; !qr.function[y][x] && i < total*8 {
+					if qr.modules[y][x] {
+						codewords[i/8] |= 1 << (7 - i%8)
+					}
+					i++
+				}
+			}
+		}
+	}
+	return codewords
+}
+
+func TestEncodeQR(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		text        string
+		wantVersion int
+	}{
+		{name: "version_1", text: "https://x.io", wantVersion: 1},
+		{name: "release_url", text: "https://github.com/acme/api/releases/tag/v1.2.0", wantVersion: 4},
+		{name: "version_7", text: strings.Repeat("a", 120), wantVersion: 7},
+		{name: "largest", text: strings.Repeat("a", 213), wantVersion: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qr, err := encodeQR(tt.text)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := 4*tt.wantVersion + 17; qr.size != want {
+				t.Fatalf("expected version %d (size %d), got size %d", tt.wantVersion, want, qr.size)
+			}
+
+			want := qrAddErrorCorrection(qrEncodeData([]byte(tt.text), tt.wantVersion), qrVersionsM[tt.wantVersion-1])
+			if got := readQR(t, qr, tt.wantVersion); !bytes.Equal(got, want) {
+				t.Errorf("codewords read back differ from those encoded")
+			}
+		})
+	}
+
+	if _, err := encodeQR(strings.Repeat("a", 214)); err == nil {
+		t.Error("expected an error for text over the version 10 capacity")
+	}
+}
+
+func TestQRCodeImage(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version:       "1.2.0",
+		TagName:       "v1.2.0",
+		RepositoryURL: "https://github.com/acme/api",
+	}
+
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "enabled", enabled: true},
+		{name: "disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"webhook_url":  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"show_qr_code": tt.enabled,
+				},
+				Context: releaseCtx,
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			var images []AdaptiveElement
+			for _, elem := range decodeCard(t, bodies[0]).Body {
+				if elem.Type == "Image" {
+					images = append(images, elem)
+				}
+			}
+			if !tt.enabled {
+				if len(images) != 0 {
+					t.Errorf("expected no image, got %+v", images)
+				}
+				return
+			}
+			if len(images) != 1 {
+				t.Fatalf("expected one QR code image, got %d", len(images))
+			}
+
+			const prefix = "data:image/png;base64,"
+			if !strings.HasPrefix(images[0].URL, prefix) {
+				t.Fatalf("expected a PNG data URL, got %.40q", images[0].URL)
+			}
+			data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(images[0].URL, prefix))
+			if err != nil {
+				t.Fatalf("invalid base64: %v", err)
+			}
+			if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+				t.Errorf("invalid PNG: %v", err)
+			}
+			if !strings.Contains(images[0].AltText, "https://github.com/acme/api/releases/tag/v1.2.0") {
+				t.Errorf("expected the alt text to name the release URL, got %q", images[0].AltText)
+			}
+		})
+	}
+}
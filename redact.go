@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// redactedText replaces matches of redact_error_patterns.
+const redactedText = "***"
+
+// compileRedactPatterns compiles redact_error_patterns, skipping invalid
+// expressions, which Validate reports.
+func compileRedactPatterns(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		if re, err := regexp.Compile(pattern); err == nil {
+			compiled = append(compiled, re)
+		}
+	}
+	return compiled
+}
+
+// redactErrorText replaces every match of the configured patterns in text
+// with "***". It runs before escaping so patterns see the raw error text.
+func (cfg *Config) redactErrorText(text string) string {
+	for _, re := range compileRedactPatterns(cfg.RedactErrorPatterns) {
+		text = re.ReplaceAllString(text, redactedText)
+	}
+	return text
+}
+
+// validateRedactPatterns reports redact_error_patterns that are not valid regexps.
+func validateRedactPatterns(vb *helpers.ValidationBuilder, patterns []string) {
+	for i, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			vb.AddErrorWithCode("redact_error_patterns",
+				fmt.Sprintf("redact_error_patterns[%d] is not a valid regular expression: %v", i, err),
+				"format")
+		}
+	}
+}
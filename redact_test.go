@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRedactErrorPatterns(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnError,
+		Config: map[string]any{
+			"webhook_url":           "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"redact_error_patterns": []any{`ghp_[A-Za-z0-9]+`, `(?i)password=[^\s)]+`},
+		},
+		Context: plugin.ReleaseContext{
+			Version: "1.0.0",
+			Environment: map[string]string{
+				EnvErrorMessage: "push failed: token ghp_abc123XYZ rejected\nat deploy (PASSWORD=hunter2) <main>",
+			},
+		},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	payload := string(bodies[0])
+	for _, secret := range []string{"ghp_abc123XYZ", "hunter2"} {
+		if strings.Contains(payload, secret) {
+			t.Errorf("expected %q to be redacted, payload: %s", secret, payload)
+		}
+	}
+
+	card := decodeCard(t, bodies[0])
+	want := "push failed: token *** rejected\nat deploy (***) &lt;main&gt;"
+	found := false
+	for _, elem := range card.Body {
+		if elem.Text == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected redacted, escaped error message %q in card", want)
+	}
+}
+
+func TestRedactCoalescedErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{RedactErrorPatterns: []string{`secret-\d+`}}
+	n := (&TeamsPlugin{}).buildErrorSummaryNotification(cfg, []plugin.ReleaseContext{
+		{Version: "1.0.0", Environment: map[string]string{EnvErrorMessage: "build used secret-1"}},
+		{Version: "1.0.0", Environment: map[string]string{EnvErrorMessage: "publish used secret-2"}},
+	})
+
+	list := n.body[len(n.body)-1].Text
+	if list != "- build used ***\n- publish used ***" {
+		t.Errorf("unexpected failure list %q", list)
+	}
+}
+
+func TestValidateRedactErrorPatterns(t *testing.T) {
+	t.Parallel()
+
+	resp, err := (&TeamsPlugin{}).Validate(context.Background(), map[string]any{
+		"webhook_url":           "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"redact_error_patterns": []any{`token=\w+`, `(unclosed`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Valid || len(resp.Errors) != 1 || !strings.HasPrefix(resp.Errors[0].Message, "redact_error_patterns[1] is not a valid regular expression") {
+		t.Errorf("expected invalid pattern to be reported, got %+v", resp.Errors)
+	}
+}
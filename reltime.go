@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// EnvReleasedAt carries the release time (RFC 3339) in the release environment.
+const EnvReleasedAt = "RELICTA_RELEASED_AT"
+
+// relativeTimeLimit is the age after which relative times fall back to an
+// absolute timestamp; "Released 3 days ago" is less useful than a date.
+const relativeTimeLimit = 24 * time.Hour
+
+// resolveReleasedAt returns the release time from released_at or the release
+// environment. ok is false when neither holds a valid RFC 3339 timestamp.
+func resolveReleasedAt(cfg *Config, releaseCtx plugin.ReleaseContext) (time.Time, bool) {
+	value := cfg.ReleasedAt
+	if value == "" {
+		value = releaseCtx.Environment[EnvReleasedAt]
+	}
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// humanizeSince describes t relative to now, such as "just now" or
+// "2 minutes ago". Times older than relativeTimeLimit, or more than a minute
+// in the future, are formatted as an absolute UTC timestamp instead.
+func humanizeSince(t, now time.Time) string {
+	age := now.Sub(t)
+	switch {
+	case age < -time.Minute || age >= relativeTimeLimit:
+		return "on " + t.UTC().Format("2006-01-02 15:04 UTC")
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return pluralize(int(age/time.Minute), "minute") + " ago"
+	default:
+		return pluralize(int(age/time.Hour), "hour") + " ago"
+	}
+}
+
+// pluralize formats n with unit, adding an "s" unless n is 1.
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestHumanizeSince(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		ago  time.Duration
+		want string
+	}{
+		{name: "just now", ago: 10 * time.Second, want: "just now"},
+		{name: "slightly in the future", ago: -30 * time.Second, want: "just now"},
+		{name: "one minute", ago: time.Minute, want: "1 minute ago"},
+		{name: "minutes", ago: 2*time.Minute + 30*time.Second, want: "2 minutes ago"},
+		{name: "one hour", ago: time.Hour + 5*time.Minute, want: "1 hour ago"},
+		{name: "hours", ago: 5 * time.Hour, want: "5 hours ago"},
+		{name: "old", ago: 3 * 24 * time.Hour, want: "on 2024-05-07 12:00 UTC"},
+		{name: "future", ago: -time.Hour, want: "on 2024-05-10 13:00 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := humanizeSince(now.Add(-tt.ago), now); got != tt.want {
+				t.Errorf("humanizeSince(-%s) = %q, want %q", tt.ago, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelativeTimeRendered(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	p := &TeamsPlugin{now: func() time.Time { return now }}
+	cfg := &Config{RelativeTime: true}
+	releaseCtx := plugin.ReleaseContext{
+		Version:     "1.0.0",
+		Environment: map[string]string{EnvReleasedAt: "2024-05-01T11:57:00Z"},
+	}
+	n := p.buildSuccessNotification(cfg, releaseCtx)
+	if got := n.body[1].Text; got != "Released 3 minutes ago" || !n.body[1].IsSubtle {
+		t.Errorf("expected subtle relative time under the title, got %+v", n.body[1])
+	}
+
+	// Invalid or missing timestamps omit the line
+	releaseCtx.Environment[EnvReleasedAt] = "yesterday"
+	n = p.buildSuccessNotification(cfg, releaseCtx)
+	for _, elem := range n.body {
+		if strings.HasPrefix(elem.Text, "Released ") {
+			t.Errorf("expected no relative time for invalid timestamp, got %q", elem.Text)
+		}
+	}
+}
+
+func TestValidateReleasedAt(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for value, wantValid := range map[string]bool{"2024-05-10T12:00:00Z": true, "2024-05-10T12:00:00+02:00": true, "10 May 2024": false} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"released_at": value,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("released_at %q: expected Valid=%v, got %+v", value, wantValid, resp.Errors)
+		}
+	}
+}
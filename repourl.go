@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+// repositoryWebURL converts a repository remote to the HTTPS web URL used for
+// release links. It accepts HTTPS remotes, SCP-style SSH remotes
+// (git@github.com:org/repo.git) and ssh:// or git:// URLs. Credentials, SSH
+// ports and the ".git" suffix are dropped. Unrecognized input is returned
+// with only surrounding slashes and the ".git" suffix removed.
+func repositoryWebURL(remote string) string {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return ""
+	}
+
+	// SCP-style remotes have no scheme: [user@]host:path
+	if !strings.Contains(remote, "://") {
+		if host, path, ok := strings.Cut(remote, ":"); ok && path != "" && !strings.HasPrefix(path, "/") {
+			if _, h, found := strings.Cut(host, "@"); found {
+				host = h
+			}
+			return "https://" + host + "/" + trimRepositoryPath(path)
+		}
+		return trimRepositoryPath(remote)
+	}
+
+	parsed, err := url.Parse(remote)
+	if err != nil || parsed.Host == "" {
+		return trimRepositoryPath(remote)
+	}
+
+	host := parsed.Host
+	switch parsed.Scheme {
+	case "ssh", "git", "git+ssh":
+		// SSH and git-protocol ports are not web ports
+		host = parsed.Hostname()
+	case "http", "https":
+	default:
+		return trimRepositoryPath(remote)
+	}
+
+	web := url.URL{Scheme: "https", Host: host, Path: "/" + trimRepositoryPath(parsed.Path)}
+	if parsed.Scheme == "http" {
+		web.Scheme = "http"
+	}
+	return web.String()
+}
+
+// trimRepositoryPath removes surrounding slashes and the ".git" suffix.
+func trimRepositoryPath(path string) string {
+	path = strings.Trim(path, "/")
+	return strings.TrimSuffix(path, ".git")
+}
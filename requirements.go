@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// requirement describes a feature that only works with companion fields set.
+type requirement struct {
+	// feature is the config key enabling the feature.
+	feature string
+	// companions lists config keys of which at least one must be set.
+	companions []string
+	enabled    func(parser *helpers.ConfigParser) bool
+	satisfied  func(parser *helpers.ConfigParser) bool
+}
+
+// requirements is the table of cross-field checks run by Validate.
+var requirements = []requirement{
+	{
+		feature:    "chunk_mentions",
+		companions: []string{"max_mentions"},
+		enabled: func(parser *helpers.ConfigParser) bool {
+			return parser.GetBool("chunk_mentions", false)
+		},
+		satisfied: func(parser *helpers.ConfigParser) bool {
+			return parser.GetInt("max_mentions", 0) > 0
+		},
+	},
+	{
+		feature:    "config_resolution_retries",
+		companions: []string{"webhook_url_file", "mention_users_file"},
+		enabled: func(parser *helpers.ConfigParser) bool {
+			return parser.GetInt("config_resolution_retries", 0) > 0
+		},
+		satisfied: func(parser *helpers.ConfigParser) bool {
+			return parser.GetString("webhook_url_file", "", "") != "" ||
+				parser.GetString("mention_users_file", "", "") != ""
+		},
+	},
+	graphCredentialRequirement("graph_tenant_id", EnvGraphTenantID),
+	graphCredentialRequirement("graph_client_id", EnvGraphClientID),
+	graphCredentialRequirement("graph_client_secret", EnvGraphClientSecret),
+}
+
+// graphCredentialRequirement requires a Graph credential, from config or its
+// environment variable, when resolve_mentions is enabled.
+func graphCredentialRequirement(key, envKey string) requirement {
+	return requirement{
+		feature:    "resolve_mentions",
+		companions: []string{key},
+		enabled: func(parser *helpers.ConfigParser) bool {
+			return parser.GetBool("resolve_mentions", false)
+		},
+		satisfied: func(parser *helpers.ConfigParser) bool {
+			return parser.GetString(key, envKey, "") != ""
+		},
+	}
+}
+
+// validateRequirements reports features enabled without their companion fields.
+func validateRequirements(parser *helpers.ConfigParser, vb *helpers.ValidationBuilder) {
+	for _, req := range requirements {
+		if !req.enabled(parser) || req.satisfied(parser) {
+			continue
+		}
+		vb.AddErrorWithCode(req.companions[0],
+			fmt.Sprintf("%s requires %s to be set", req.feature, strings.Join(req.companions, " or ")),
+			"required")
+	}
+}
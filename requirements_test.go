@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateRequirements(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:      "chunk_mentions_without_max_mentions",
+			config:    map[string]any{"chunk_mentions": true},
+			wantField: "max_mentions",
+			wantMsg:   "chunk_mentions requires max_mentions to be set",
+		},
+		{
+			name:      "config_resolution_retries_without_files",
+			config:    map[string]any{"config_resolution_retries": 3},
+			wantField: "webhook_url_file",
+			wantMsg:   "config_resolution_retries requires webhook_url_file or mention_users_file to be set",
+		},
+		{
+			name:   "chunk_mentions_with_max_mentions",
+			config: map[string]any{"chunk_mentions": true, "max_mentions": 5},
+		},
+		{
+			name:   "config_resolution_retries_with_mention_file",
+			config: map[string]any{"config_resolution_retries": 3, "mention_users_file": "/run/secrets/mentions"},
+		},
+		{
+			name:      "resolve_mentions_without_secret",
+			config:    map[string]any{"resolve_mentions": true, "graph_tenant_id": "tenant", "graph_client_id": "app"},
+			wantField: "graph_client_secret",
+			wantMsg:   "resolve_mentions requires graph_client_secret to be set",
+		},
+		{
+			name: "resolve_mentions_with_credentials",
+			config: map[string]any{
+				"resolve_mentions":    true,
+				"graph_tenant_id":     "tenant",
+				"graph_client_id":     "app",
+				"graph_client_secret": "secret",
+			},
+		},
+		{
+			name:   "features_disabled",
+			config: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, ok := tt.config["webhook_url"]; !ok {
+				tt.config["webhook_url"] = webhook
+			}
+
+			p := &TeamsPlugin{}
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got %+v", resp.Errors)
+				}
+				return
+			}
+
+			if resp.Valid || len(resp.Errors) != 1 {
+				t.Fatalf("expected a single error, got %+v", resp.Errors)
+			}
+			got := resp.Errors[0]
+			if got.Field != tt.wantField || got.Code != "required" || got.Message != tt.wantMsg {
+				t.Errorf("unexpected error: %+v", got)
+			}
+		})
+	}
+}
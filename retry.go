@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Retry defaults and limits.
+const (
+	DefaultMaxRetries     = 3
+	DefaultRetryBackoffMS = 500
+	MaxRetries            = 10
+	MaxRetryBackoffMS     = 60000
+
+	// maxRetryDelay caps a single exponential backoff interval.
+	maxRetryDelay = 30 * time.Second
+	// maxRetryAfter caps the wait a Retry-After header can request.
+	maxRetryAfter = 5 * time.Minute
+)
+
+// Retry strategies accepted by retry_strategy.
+const (
+	RetryStrategyExponential = "exponential"
+	RetryStrategyFixed       = "fixed"
+)
+
+// statusError is returned when Teams responds with an unexpected status code.
+type statusError struct {
+	StatusCode int
+	// RetryAfter is the wait requested by a Retry-After header, or 0.
+	RetryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("teams returned status %d", e.StatusCode)
+}
+
+// transportError wraps a failure to get any response from the webhook.
+// attemptTimedOut marks an attempt that ran out its request_timeout_seconds
+// while the caller's context was still live.
+type transportError struct {
+	err             error
+	attemptTimedOut bool
+}
+
+func (e *transportError) Error() string { return e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+// isRetryable reports whether a send error is transient: a network failure,
+// a timed-out attempt, a 5xx response or rate limiting (429). Other client
+// errors are never retried.
+func isRetryable(err error) bool {
+	var te *transportError
+	if errors.As(err, &te) && te.attemptTimedOut {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, errDisallowedAddress) {
+		return false
+	}
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.StatusCode >= http.StatusInternalServerError || se.StatusCode == http.StatusTooManyRequests
+	}
+	return errors.As(err, &te)
+}
+
+// retryDelay returns the pause before the given retry (1-based).
+// Fixed mode waits exactly the base interval. Exponential mode doubles the
+// base each retry and picks a random point in the upper half of the interval.
+func retryDelay(cfg *Config, retry int) time.Duration {
+	base := time.Duration(cfg.RetryBackoffMS) * time.Millisecond
+	if cfg.RetryStrategy == RetryStrategyFixed || base <= 0 {
+		return base
+	}
+
+	delay := base
+	for i := 1; i < retry && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter reads a Retry-After header in either the delay-seconds or
+// the HTTP-date form. It returns 0 when the header is absent or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = at.Sub(now)
+	}
+	return min(max(wait, 0), maxRetryAfter)
+}
+
+// nextRetryDelay returns the pause before the given retry (1-based): the
+// server's Retry-After when err carries one, otherwise the backoff. The pause
+// never outlasts the context deadline.
+func nextRetryDelay(ctx context.Context, cfg *Config, retry int, err error) time.Duration {
+	delay := retryDelay(cfg, retry)
+	var se *statusError
+	if errors.As(err, &se) && se.RetryAfter > 0 {
+		delay = se.RetryAfter
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		delay = min(delay, max(time.Until(deadline), 0))
+	}
+	return delay
+}
+
+// sendWithRetry sends the message, retrying transient failures according to cfg.
+func (p *TeamsPlugin) sendWithRetry(ctx context.Context, cfg *Config, webhookURL string, msg TeamsMessage) error {
+	logger := p.getLogger()
+
+	ctx, span := p.startSpan(ctx, spanSend,
+		attrWebhook.String(redactWebhookURL(webhookURL)),
+		attrMethod.String(http.MethodPost))
+	defer span.End()
+
+	client, err := p.httpClientFor(cfg)
+	if err != nil {
+		endSendSpan(span, 0, err, webhookURL)
+		return err
+	}
+
+	attempt := 1
+	for ; ; attempt++ {
+		countAttempt(ctx)
+		err = p.sendRequest(ctx, client, webhookURL, msg, sendOptionsFor(cfg))
+		if err == nil || attempt > cfg.MaxRetries || !isRetryable(err) {
+			break
+		}
+
+		p.notifyRetry(attempt+1, err, webhookURL)
+
+		delay := nextRetryDelay(ctx, cfg, attempt, err)
+		logger.Warn("retrying Teams message",
+			"webhook", redactWebhookURL(webhookURL),
+			"attempt", attempt+1,
+			"delay", delay.String(),
+			"error", redactError(err, webhookURL))
+		if sleepErr := p.sleep(ctx, delay); sleepErr != nil {
+			err = fmt.Errorf("retry aborted after %d attempts: %w", attempt, sleepErr)
+			p.notifySendResult(attempt, err, webhookURL)
+			endSendSpan(span, attempt, err, webhookURL)
+			return err
+		}
+	}
+
+	p.notifySendResult(attempt, err, webhookURL)
+	endSendSpan(span, attempt, err, webhookURL)
+	if err != nil && attempt > 1 {
+		return fmt.Errorf("%w (after %d attempts)", err, attempt)
+	}
+	return err
+}
+
+// notifyRetry calls OnRetry, if set, with the webhook URL redacted from err.
+func (p *TeamsPlugin) notifyRetry(attempt int, err error, webhookURL string) {
+	if p.OnRetry == nil {
+		return
+	}
+	defer p.recoverCallback("OnRetry")
+	p.OnRetry(attempt, redactedError(err, webhookURL))
+}
+
+// notifySendResult calls OnSendResult, if set, with the webhook URL redacted from err.
+func (p *TeamsPlugin) notifySendResult(attempts int, err error, webhookURL string) {
+	if p.OnSendResult == nil {
+		return
+	}
+	defer p.recoverCallback("OnSendResult")
+	p.OnSendResult(attempts, redactedError(err, webhookURL))
+}
+
+// recoverCallback logs a panicking host callback so it never interrupts a send.
+func (p *TeamsPlugin) recoverCallback(name string) {
+	if r := recover(); r != nil {
+		p.getLogger().Error("callback panicked", "callback", name, "panic", fmt.Sprint(r))
+	}
+}
+
+// redactedError returns err with the webhook URL redacted from its message,
+// or nil when err is nil.
+func redactedError(err error, webhookURL string) error {
+	if err == nil {
+		return nil
+	}
+	return errors.New(redactError(err, webhookURL))
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// statusSequenceClient returns a mock client that replies with the given
+// status codes in order, repeating the last one, and counts calls.
+func statusSequenceClient(calls *int, statuses ...int) *MockHTTPClient {
+	var mu sync.Mutex
+	return &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			status := statuses[len(statuses)-1]
+			if *calls < len(statuses) {
+				status = statuses[*calls]
+			}
+			*calls++
+			return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		},
+	}
+}
+
+// recordSleeps returns a sleep function that records requested delays without waiting.
+func recordSleeps(delays *[]time.Duration) func(context.Context, time.Duration) error {
+	var mu sync.Mutex
+	return func(_ context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*delays = append(*delays, d)
+		return nil
+	}
+}
+
+func TestRetryStrategies(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+
+	t.Run("fixed_intervals", func(t *testing.T) {
+		var calls int
+		var delays []time.Duration
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusServiceUnavailable),
+			sleepFunc:  recordSleeps(&delays),
+		}
+		cfg := &Config{MaxRetries: 4, RetryBackoffMS: 250, RetryStrategy: RetryStrategyFixed}
+
+		err := p.sendWithRetry(context.Background(), cfg, webhook, TeamsMessage{})
+		if err == nil {
+			t.Fatal("expected error after exhausting retries")
+		}
+		if calls != 5 {
+			t.Errorf("expected 5 attempts, got %d", calls)
+		}
+		for i, d := range delays {
+			if d != 250*time.Millisecond {
+				t.Errorf("delay %d: expected fixed 250ms, got %v", i, d)
+			}
+		}
+	})
+
+	t.Run("exponential_growth", func(t *testing.T) {
+		var calls int
+		var delays []time.Duration
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusBadGateway),
+			sleepFunc:  recordSleeps(&delays),
+		}
+		cfg := &Config{MaxRetries: 4, RetryBackoffMS: 100, RetryStrategy: RetryStrategyExponential}
+
+		_ = p.sendWithRetry(context.Background(), cfg, webhook, TeamsMessage{})
+		if len(delays) != 4 {
+			t.Fatalf("expected 4 delays, got %d", len(delays))
+		}
+		for i, d := range delays {
+			ceiling := 100 * time.Millisecond << i
+			if d < ceiling/2 || d > ceiling {
+				t.Errorf("delay %d: expected within [%v, %v], got %v", i, ceiling/2, ceiling, d)
+			}
+			if i > 0 && d <= delays[i-1] {
+				t.Errorf("delay %d: expected growth over %v, got %v", i, delays[i-1], d)
+			}
+		}
+	})
+
+	t.Run("recovers_after_transient_failure", func(t *testing.T) {
+		var calls int
+		var delays []time.Duration
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusInternalServerError, http.StatusOK),
+			sleepFunc:  recordSleeps(&delays),
+		}
+		cfg := &Config{MaxRetries: 3, RetryBackoffMS: 10}
+
+		if err := p.sendWithRetry(context.Background(), cfg, webhook, TeamsMessage{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("expected 2 attempts, got %d", calls)
+		}
+	})
+
+	t.Run("client_errors_not_retried", func(t *testing.T) {
+		var calls int
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusBadRequest),
+			sleepFunc:  recordSleeps(new([]time.Duration)),
+		}
+		cfg := &Config{MaxRetries: 3, RetryBackoffMS: 10}
+
+		err := p.sendWithRetry(context.Background(), cfg, webhook, TeamsMessage{})
+		if err == nil || calls != 1 {
+			t.Errorf("expected a single failed attempt, got %d attempts (err=%v)", calls, err)
+		}
+	})
+
+	t.Run("error_reports_attempts", func(t *testing.T) {
+		var calls int
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusInternalServerError),
+			sleepFunc:  recordSleeps(new([]time.Duration)),
+		}
+		cfg := &Config{MaxRetries: 2, RetryBackoffMS: 10}
+
+		err := p.sendWithRetry(context.Background(), cfg, webhook, TeamsMessage{})
+		if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+			t.Errorf("expected attempt count in error, got %v", err)
+		}
+	})
+
+	t.Run("default_retries", func(t *testing.T) {
+		var calls int
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusServiceUnavailable),
+			sleepFunc:  recordSleeps(new([]time.Duration)),
+		}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  map[string]any{"webhook_url": webhook},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil || resp.Success {
+			t.Fatalf("expected a failed send, got %v %+v", err, resp)
+		}
+		if calls != DefaultMaxRetries+1 {
+			t.Errorf("expected %d attempts by default, got %d", DefaultMaxRetries+1, calls)
+		}
+		if !strings.Contains(resp.Error, "after 4 attempts") {
+			t.Errorf("expected attempt count in error, got %q", resp.Error)
+		}
+	})
+}
+
+func TestRetryCallbacks(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+
+	t.Run("retried_then_succeeded", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		var delays []time.Duration
+		var retries []int
+		var retryErrs []error
+		var results []int
+		var resultErr error
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK),
+			sleepFunc:  recordSleeps(&delays),
+			OnRetry: func(attempt int, err error) {
+				retries = append(retries, attempt)
+				retryErrs = append(retryErrs, err)
+			},
+			OnSendResult: func(attempts int, err error) {
+				results = append(results, attempts)
+				resultErr = err
+			},
+		}
+
+		if err := p.sendWithRetry(context.Background(), &Config{MaxRetries: 3}, webhook, TeamsMessage{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(retries) != 2 || retries[0] != 2 || retries[1] != 3 {
+			t.Errorf("expected retries for attempts 2 and 3, got %v", retries)
+		}
+		if retryErrs[0] == nil || retryErrs[0].Error() != "teams returned status 503" {
+			t.Errorf("expected the 503 as the first retry cause, got %v", retryErrs[0])
+		}
+		if len(results) != 1 || results[0] != 3 || resultErr != nil {
+			t.Errorf("expected one successful result after 3 attempts, got %v %v", results, resultErr)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		var attempts int
+		var resultErr error
+		p := &TeamsPlugin{
+			httpClient: statusSequenceClient(&calls, http.StatusBadRequest),
+			OnRetry:    func(int, error) { t.Error("client errors must not be retried") },
+			OnSendResult: func(n int, err error) {
+				attempts, resultErr = n, err
+			},
+		}
+
+		if err := p.sendWithRetry(context.Background(), &Config{MaxRetries: 3}, webhook, TeamsMessage{}); err == nil {
+			t.Fatal("expected an error")
+		}
+		if attempts != 1 || resultErr == nil || resultErr.Error() != "teams returned status 400" {
+			t.Errorf("expected a failed result after 1 attempt, got %d %v", attempts, resultErr)
+		}
+	})
+
+	t.Run("panicking_callbacks", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+		var delays []time.Duration
+		logger := &captureLogger{}
+		p := &TeamsPlugin{
+			httpClient:   statusSequenceClient(&calls, http.StatusServiceUnavailable, http.StatusOK),
+			sleepFunc:    recordSleeps(&delays),
+			Logger:       logger,
+			OnRetry:      func(int, error) { panic("retry metrics down") },
+			OnSendResult: func(int, error) { panic("result metrics down") },
+		}
+
+		if err := p.sendWithRetry(context.Background(), &Config{MaxRetries: 1}, webhook, TeamsMessage{}); err != nil {
+			t.Fatalf("expected callback panics to be contained, got %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("expected the retry to proceed, got %d attempts", calls)
+		}
+		if _, ok := logger.find("error", "callback panicked"); !ok {
+			t.Error("expected the panic to be logged")
+		}
+	})
+}
+
+func TestValidateRetryOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		key       string
+		value     any
+		wantValid bool
+	}{
+		{name: "fixed_strategy", key: "retry_strategy", value: "fixed", wantValid: true},
+		{name: "unknown_strategy", key: "retry_strategy", value: "linear", wantValid: false},
+		{name: "negative_retries", key: "max_retries", value: -1, wantValid: false},
+		{name: "too_many_retries", key: "max_retries", value: 11, wantValid: false},
+		{name: "backoff_too_large", key: "retry_backoff_ms", value: 60001, wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &TeamsPlugin{}
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+				tt.key:        tt.value,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Errorf("expected Valid=%v, got %v (%+v)", tt.wantValid, resp.Valid, resp.Errors)
+			}
+		})
+	}
+}
+
+// rateLimitedClient replies 429 with the given Retry-After header, then 200.
+func rateLimitedClient(calls *int, retryAfter string) *MockHTTPClient {
+	var mu sync.Mutex
+	return &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			*calls++
+			if *calls == 1 {
+				header := http.Header{}
+				if retryAfter != "" {
+					header.Set("Retry-After", retryAfter)
+				}
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		},
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		wantDelay  time.Duration
+	}{
+		{name: "seconds", retryAfter: "2", wantDelay: 2 * time.Second},
+		{name: "http_date", retryAfter: now.Add(5 * time.Second).Format(http.TimeFormat), wantDelay: 5 * time.Second},
+		{name: "past_date_uses_backoff", retryAfter: now.Add(-time.Minute).Format(http.TimeFormat), wantDelay: 250 * time.Millisecond},
+		{name: "capped", retryAfter: "86400", wantDelay: maxRetryAfter},
+		{name: "absent_uses_backoff", wantDelay: 250 * time.Millisecond},
+		{name: "invalid_uses_backoff", retryAfter: "soon", wantDelay: 250 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			var delays []time.Duration
+			p := &TeamsPlugin{
+				httpClient: rateLimitedClient(&calls, tt.retryAfter),
+				sleepFunc:  recordSleeps(&delays),
+				now:        func() time.Time { return now },
+			}
+			cfg := &Config{MaxRetries: 3, RetryBackoffMS: 250, RetryStrategy: RetryStrategyFixed}
+
+			if err := p.sendWithRetry(context.Background(), cfg, webhook, TeamsMessage{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if calls != 2 {
+				t.Errorf("expected the request to be retried once, got %d attempts", calls)
+			}
+			if len(delays) != 1 || delays[0] != tt.wantDelay {
+				t.Errorf("expected a %v wait, got %v", tt.wantDelay, delays)
+			}
+		})
+	}
+}
+
+func TestRetryAfterCappedByDeadline(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	var delays []time.Duration
+	p := &TeamsPlugin{
+		httpClient: rateLimitedClient(&calls, "60"),
+		sleepFunc:  recordSleeps(&delays),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cfg := &Config{MaxRetries: 3, RetryBackoffMS: 10}
+	_ = p.sendWithRetry(ctx, cfg, "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789", TeamsMessage{})
+	if len(delays) != 1 || delays[0] > time.Second {
+		t.Errorf("expected the wait to be capped by the 1s deadline, got %v", delays)
+	}
+}
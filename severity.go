@@ -0,0 +1,38 @@
+package main
+
+// Severity levels accepted by min_severity, in ascending order.
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// severityRank orders severities for comparison.
+var severityRank = map[string]int{
+	SeverityInfo:    0,
+	SeverityWarning: 1,
+	SeverityError:   2,
+}
+
+// statusSeverity maps a notification status to its severity. Approvals are
+// warnings: a release is waiting on a person, but nothing has failed.
+func statusSeverity(status string) string {
+	switch status {
+	case StatusError:
+		return SeverityError
+	case StatusApproval:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// meetsMinSeverity reports whether a notification status is at or above minSeverity.
+// An empty or unknown minimum allows everything.
+func meetsMinSeverity(status, minSeverity string) bool {
+	minRank, ok := severityRank[minSeverity]
+	if !ok {
+		return true
+	}
+	return severityRank[statusSeverity(status)] >= minRank
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestMeetsMinSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status      string
+		minSeverity string
+		want        bool
+	}{
+		{StatusSuccess, SeverityInfo, true},
+		{StatusSuccess, SeverityWarning, false},
+		{StatusSuccess, SeverityError, false},
+		{StatusApproval, SeverityInfo, true},
+		{StatusApproval, SeverityWarning, true},
+		{StatusApproval, SeverityError, false},
+		{StatusError, SeverityWarning, true},
+		{StatusError, SeverityError, true},
+		{StatusSuccess, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status+"_"+tt.minSeverity, func(t *testing.T) {
+			if got := meetsMinSeverity(tt.status, tt.minSeverity); got != tt.want {
+				t.Errorf("meetsMinSeverity(%q, %q) = %v, want %v", tt.status, tt.minSeverity, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteMinSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		hook        plugin.Hook
+		config      map[string]any
+		wantMessage string
+	}{
+		{
+			name:        "error_min_suppresses_success",
+			hook:        plugin.HookPostPublish,
+			config:      map[string]any{"min_severity": "error"},
+			wantMessage: "Notification below min_severity error",
+		},
+		{
+			name:        "error_min_sends_errors",
+			hook:        plugin.HookOnError,
+			config:      map[string]any{"min_severity": "error"},
+			wantMessage: "Would send Teams error notification",
+		},
+		{
+			name:        "notify_flag_still_disables",
+			hook:        plugin.HookOnError,
+			config:      map[string]any{"min_severity": "error", "notify_on_error": false},
+			wantMessage: "Error notification disabled",
+		},
+		{
+			name:        "warning_min_suppresses_success",
+			hook:        plugin.HookPostPublish,
+			config:      map[string]any{"min_severity": "warning"},
+			wantMessage: "Notification below min_severity warning",
+		},
+		{
+			name:        "warning_min_sends_approvals",
+			hook:        plugin.HookPostApprove,
+			config:      map[string]any{"min_severity": "warning", "notify_on_approval": true},
+			wantMessage: "Would send Teams approval notification",
+		},
+		{
+			name:        "error_min_suppresses_approvals",
+			hook:        plugin.HookPostApprove,
+			config:      map[string]any{"min_severity": "error", "notify_on_approval": true},
+			wantMessage: "Notification below min_severity error",
+		},
+		{
+			name:        "warning_min_sends_errors",
+			hook:        plugin.HookOnError,
+			config:      map[string]any{"min_severity": "warning"},
+			wantMessage: "Would send Teams error notification",
+		},
+		{
+			name:        "default_sends_success",
+			hook:        plugin.HookPostPublish,
+			config:      map[string]any{},
+			wantMessage: "Would send Teams success notification",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["webhook_url"] = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+			p := &TeamsPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    tt.hook,
+				Config:  tt.config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+				DryRun:  true,
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %s", err, resp.Error)
+			}
+			if !strings.HasPrefix(resp.Message, tt.wantMessage) {
+				t.Errorf("expected message %q, got %q", tt.wantMessage, resp.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Reasons a notification is skipped, as counted by report_skips.
+const (
+	SkipDisabled         = "disabled"
+	SkipApprovalDisabled = "approval-disabled"
+	SkipBelowSeverity    = "below-min-severity"
+	SkipErrorDisabled    = "error-disabled"
+	SkipSuccessDisabled  = "success-disabled"
+	SkipEmptyRelease     = "empty-release"
+	SkipDuplicate        = "duplicate"
+	SkipMarker           = "skip-marker"
+	SkipCooldown         = "cooldown"
+)
+
+// skipLedger counts skipped notifications by reason until they are reported.
+type skipLedger struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newSkipLedger() *skipLedger {
+	return &skipLedger{counts: make(map[string]int)}
+}
+
+// record counts one skipped notification.
+func (l *skipLedger) record(reason string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.counts[reason]++
+}
+
+// drain removes and returns the counts.
+func (l *skipLedger) drain() map[string]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	counts := l.counts
+	l.counts = make(map[string]int)
+	return counts
+}
+
+// restore adds back counts that could not be reported.
+func (l *skipLedger) restore(counts map[string]int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for reason, n := range counts {
+		l.counts[reason] += n
+	}
+}
+
+// defaultSkipLedger is shared by plugin instances without an injected ledger,
+// so skips from separate hook invocations in one process are reported together.
+var defaultSkipLedger = newSkipLedger()
+
+// getSkipLedger returns the skip ledger to use.
+func (p *TeamsPlugin) getSkipLedger() *skipLedger {
+	if p.skipLedger != nil {
+		return p.skipLedger
+	}
+	return defaultSkipLedger
+}
+
+// skip returns the response for a skipped notification, counting it when
+// report_skips is enabled.
+func (p *TeamsPlugin) skip(cfg *Config, reason, message string) *plugin.ExecuteResponse {
+	if cfg.ReportSkips {
+		p.getSkipLedger().record(reason)
+	}
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: message,
+	}
+}
+
+// isSkipFlushHook reports whether a hook ends the run and so reports skips.
+func isSkipFlushHook(hook plugin.Hook) bool {
+	return hook == plugin.HookOnSuccess || hook == plugin.HookOnError
+}
+
+// buildSkipSummary summarizes skip counts, most frequent reason first, e.g.
+// "Suppressed 5 notifications: 3 empty-release, 2 success-disabled".
+func buildSkipSummary(counts map[string]int) string {
+	reasons := make([]string, 0, len(counts))
+	total := 0
+	for reason, n := range counts {
+		reasons = append(reasons, reason)
+		total += n
+	}
+	if total == 0 {
+		return ""
+	}
+	slices.SortFunc(reasons, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), strings.Compare(a, b))
+	})
+
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%d %s", counts[reason], reason)
+	}
+	return fmt.Sprintf("Suppressed %s: %s", pluralize(total, "notification"), strings.Join(parts, ", "))
+}
+
+// reportSkips posts the skip summary to skip_report_webhook_url, falling back
+// to the main webhook. Counts that could not be reported are kept for the next
+// flush. Like the digest, a failed report never fails the hook; it is surfaced
+// as a "skip_report_warning" output instead.
+func (p *TeamsPlugin) reportSkips(ctx context.Context, cfg *Config, resp *plugin.ExecuteResponse, dryRun bool) {
+	ledger := p.getSkipLedger()
+	counts := ledger.drain()
+	summary := buildSkipSummary(counts)
+	if summary == "" {
+		return
+	}
+	if resp.Outputs == nil {
+		resp.Outputs = map[string]any{}
+	}
+	resp.Outputs["skip_summary"] = summary
+
+	// Keep the counts so the real run still reports them
+	if dryRun {
+		ledger.restore(counts)
+		return
+	}
+
+	if err := p.sendSkipReport(ctx, cfg, summary); err != nil {
+		ledger.restore(counts)
+		p.getLogger().Warn("skip report failed", "error", err.Error())
+		resp.Outputs["skip_report_warning"] = fmt.Sprintf("failed to send skip report: %s", err)
+	}
+}
+
+// sendSkipReport posts a skip summary card.
+func (p *TeamsPlugin) sendSkipReport(ctx context.Context, cfg *Config, summary string) error {
+	webhookURL := cfg.SkipReportWebhookURL
+	if webhookURL == "" {
+		// The main webhook may still need to be read from webhook_url_file
+		if err := p.resolveConfig(ctx, cfg); err != nil {
+			return err
+		}
+		webhookURL = cfg.WebhookURL
+	}
+
+	msg := p.buildTeamsMessage([]AdaptiveElement{
+		{Type: "TextBlock", Text: summary, Wrap: true},
+	}, nil, nil, "")
+	if err := p.deliver(ctx, cfg, webhookURL, msg); err != nil {
+		return errors.New(redactError(err, webhookURL))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestBuildSkipSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   string
+	}{
+		{name: "empty", counts: map[string]int{}, want: ""},
+		{name: "single", counts: map[string]int{SkipEmptyRelease: 1}, want: "Suppressed 1 notification: 1 empty-release"},
+		{
+			name:   "ordered_by_count",
+			counts: map[string]int{SkipSuccessDisabled: 2, SkipEmptyRelease: 3},
+			want:   "Suppressed 5 notifications: 3 empty-release, 2 success-disabled",
+		},
+		{
+			name:   "ties_by_name",
+			counts: map[string]int{SkipErrorDisabled: 1, SkipDuplicate: 1},
+			want:   "Suppressed 2 notifications: 1 duplicate, 1 error-disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := buildSkipSummary(tt.counts); got != tt.want {
+				t.Errorf("buildSkipSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportSkips(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{
+		httpClient: recordingClient(&bodies),
+		skipLedger: newSkipLedger(),
+	}
+	config := map[string]any{
+		"webhook_url":             "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"skip_report_webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/admin",
+		"report_skips":            true,
+		"skip_empty_release":      true,
+		"notify_on_approval":      false,
+	}
+
+	skipped := []plugin.Hook{plugin.HookPostPublish, plugin.HookPostPublish, plugin.HookPostPublish, plugin.HookPostApprove, plugin.HookPostApprove}
+	for i, hook := range skipped {
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    hook,
+			Config:  config,
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("skip %d: unexpected failure: %v %+v", i, err, resp)
+		}
+	}
+	if len(bodies) != 0 {
+		t.Fatalf("expected skipped notifications to send nothing, got %d cards", len(bodies))
+	}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:   plugin.HookOnSuccess,
+		Config: config,
+		Context: plugin.ReleaseContext{
+			Version:      "1.0.0",
+			ReleaseNotes: "Fixed it",
+		},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	want := "Suppressed 5 notifications: 3 empty-release, 2 approval-disabled"
+	if resp.Outputs["skip_summary"] != want {
+		t.Errorf("expected skip_summary %q, got %v", want, resp.Outputs["skip_summary"])
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected the release card and the skip report, got %d cards", len(bodies))
+	}
+	if text := decodeCard(t, bodies[1]).Body[0].Text; text != want {
+		t.Errorf("expected skip report %q, got %q", want, text)
+	}
+
+	// Reported skips are cleared
+	if counts := p.getSkipLedger().drain(); len(counts) != 0 {
+		t.Errorf("expected an empty ledger, got %v", counts)
+	}
+}
+
+func TestReportSkipsKeepsCountsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{
+		httpClient: &MockHTTPClient{
+			DoFunc: func(*http.Request) (*http.Response, error) {
+				return nil, context.DeadlineExceeded
+			},
+		},
+		skipLedger: newSkipLedger(),
+	}
+	p.skipLedger.record(SkipDuplicate)
+
+	cfg := &Config{
+		WebhookURL:  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		ReportSkips: true,
+	}
+	resp := &plugin.ExecuteResponse{Success: true}
+	p.reportSkips(context.Background(), cfg, resp, false)
+
+	if !resp.Success {
+		t.Error("a failed skip report must not fail the hook")
+	}
+	warning, _ := resp.Outputs["skip_report_warning"].(string)
+	if !strings.HasPrefix(warning, "failed to send skip report") || strings.Contains(warning, "/456/789") {
+		t.Errorf("expected a redacted skip_report_warning, got %q", warning)
+	}
+	if counts := p.skipLedger.drain(); counts[SkipDuplicate] != 1 {
+		t.Errorf("expected the skip to be kept for the next report, got %v", counts)
+	}
+}
+
+func TestReportSkipsDisabled(t *testing.T) {
+	t.Parallel()
+
+	var bodies [][]byte
+	p := &TeamsPlugin{
+		httpClient: recordingClient(&bodies),
+		skipLedger: newSkipLedger(),
+	}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnSuccess,
+		Config: map[string]any{
+			"webhook_url":       "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"notify_on_success": false,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+	if len(bodies) != 0 || resp.Outputs["skip_summary"] != nil {
+		t.Errorf("expected no skip report without report_skips, got %d cards and %v", len(bodies), resp.Outputs)
+	}
+	if counts := p.skipLedger.drain(); len(counts) != 0 {
+		t.Errorf("expected skips not to be counted, got %v", counts)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// spoolFileSuffix identifies spooled messages in spool_dir.
+	spoolFileSuffix = ".teams.json"
+	// spoolDrainTimeout bounds redelivery of spooled messages, so a backlog
+	// can't use up the time left for the current notification.
+	spoolDrainTimeout = 10 * time.Second
+)
+
+// spoolEntry is a message that failed delivery, persisted for redelivery.
+// It contains the webhook URL, so spool files are private to the owner.
+type spoolEntry struct {
+	WebhookURL string       `json:"webhook_url"`
+	Message    TeamsMessage `json:"message"`
+	SpooledAt  time.Time    `json:"spooled_at"`
+}
+
+// spoolMessage writes a failed message to dir and returns the file path.
+// File names sort in spooling order.
+func spoolMessage(dir, webhookURL string, msg TeamsMessage) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create spool_dir: %w", err)
+	}
+	// MkdirAll leaves an existing directory's mode alone
+	if err := os.Chmod(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to restrict spool_dir permissions: %w", err)
+	}
+
+	data, err := json.Marshal(spoolEntry{WebhookURL: webhookURL, Message: msg, SpooledAt: time.Now().UTC()})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spool entry: %w", err)
+	}
+
+	var suffix [4]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		return "", fmt.Errorf("failed to name spool entry: %w", err)
+	}
+	name := fmt.Sprintf("%020d-%s%s", time.Now().UnixNano(), hex.EncodeToString(suffix[:]), spoolFileSuffix)
+	path := filepath.Join(dir, name)
+
+	// Write then rename so a concurrent drain never reads a partial entry
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write spool entry: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("failed to write spool entry: %w", err)
+	}
+	return path, nil
+}
+
+// spooledFiles lists spooled messages in dir, oldest first.
+func spooledFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), spoolFileSuffix) {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// drainSpool redelivers spooled messages in order, removing each once sent.
+// It stops at the first failure, since the outage is likely still ongoing,
+// and returns the number of messages delivered. Each message gets a single
+// attempt, and the whole drain is bounded by spoolDrainTimeout.
+func (p *TeamsPlugin) drainSpool(ctx context.Context, cfg *Config) int {
+	logger := p.getLogger()
+
+	ctx, cancel := context.WithTimeout(ctx, spoolDrainTimeout)
+	defer cancel()
+	drainCfg := *cfg
+	drainCfg.MaxRetries = 0
+
+	files, err := spooledFiles(cfg.SpoolDir)
+	if err != nil {
+		logger.Warn("failed to read spool_dir", "error", err.Error())
+		return 0
+	}
+
+	delivered := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			logger.Warn("failed to read spool entry", "file", filepath.Base(file), "error", err.Error())
+			continue
+		}
+		var entry spoolEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			// A corrupt entry can never be delivered; drop it
+			logger.Warn("dropping corrupt spool entry", "file", filepath.Base(file), "error", err.Error())
+			_ = os.Remove(file)
+			continue
+		}
+		// The spool directory may have been written by someone else
+		if err := cfg.validateWebhookURL(entry.WebhookURL); err != nil {
+			logger.Warn("dropping spool entry with an invalid webhook URL", "file", filepath.Base(file), "error", redactError(err, entry.WebhookURL))
+			_ = os.Remove(file)
+			continue
+		}
+
+		if err := p.deliver(ctx, &drainCfg, entry.WebhookURL, entry.Message); err != nil {
+			logger.Warn("spooled Teams message redelivery failed",
+				"webhook", redactWebhookURL(entry.WebhookURL),
+				"error", redactError(err, entry.WebhookURL))
+			return delivered
+		}
+		if err := os.Remove(file); err != nil {
+			logger.Warn("failed to remove delivered spool entry", "file", filepath.Base(file), "error", err.Error())
+		}
+		delivered++
+	}
+
+	if delivered > 0 {
+		logger.Info("redelivered spooled Teams messages", "count", delivered)
+	}
+	return delivered
+}
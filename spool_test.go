@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSpoolFailedSend(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+	spoolDir := filepath.Join(t.TempDir(), "spool")
+	config := map[string]any{
+		"webhook_url": webhook,
+		"spool_dir":   spoolDir,
+		"max_retries": 0,
+	}
+
+	// First invocation fails with a transient error and spools the card
+	var calls int
+	p := &TeamsPlugin{httpClient: statusSequenceClient(&calls, http.StatusServiceUnavailable)}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookOnError,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || !strings.HasSuffix(resp.Error, "(spooled for redelivery)") {
+		t.Fatalf("expected spooled failure, got %+v", resp)
+	}
+
+	files, err := spooledFiles(spoolDir)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one spooled file, got %v (%v)", files, err)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected private spool file, got %v", info.Mode().Perm())
+	}
+
+	// Second invocation drains the spool before sending its own card
+	var titles []string
+	p = &TeamsPlugin{httpClient: &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var msg TeamsMessage
+			if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			if req.URL.String() != webhook {
+				t.Errorf("unexpected webhook %s", req.URL)
+			}
+			titles = append(titles, msg.Attachments[0].Content.Body[0].Text)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}}
+	resp, err = p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  config,
+		Context: plugin.ReleaseContext{Version: "1.0.1"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	want := []string{"Release 1.0.0 Failed", "Release 1.0.1"}
+	if strings.Join(titles, ",") != strings.Join(want, ",") {
+		t.Errorf("expected spooled card then new card, got %v", titles)
+	}
+	if files, _ := spooledFiles(spoolDir); len(files) != 0 {
+		t.Errorf("expected drained spool, got %v", files)
+	}
+}
+
+func TestSpoolSkipsPermanentFailures(t *testing.T) {
+	t.Parallel()
+
+	spoolDir := t.TempDir()
+	var calls int
+	p := &TeamsPlugin{httpClient: statusSequenceClient(&calls, http.StatusBadRequest)}
+
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookOnError,
+		Config: map[string]any{
+			"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"spool_dir":   spoolDir,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || strings.Contains(resp.Error, "spooled") {
+		t.Errorf("expected unspooled failure, got %+v", resp)
+	}
+	if files, _ := spooledFiles(spoolDir); len(files) != 0 {
+		t.Errorf("expected nothing spooled for a 400, got %v", files)
+	}
+}
+
+func TestDrainSpoolStopsAtFailure(t *testing.T) {
+	t.Parallel()
+
+	const webhook = "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"
+	spoolDir := t.TempDir()
+	for range 2 {
+		if _, err := spoolMessage(spoolDir, webhook, TeamsMessage{Type: "message"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var calls int
+	p := &TeamsPlugin{httpClient: statusSequenceClient(&calls, http.StatusBadGateway)}
+	if delivered := p.drainSpool(context.Background(), &Config{SpoolDir: spoolDir, MaxRetries: 3}); delivered != 0 {
+		t.Errorf("expected no deliveries, got %d", delivered)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single attempt before draining stops, got %d calls", calls)
+	}
+	if files, _ := spooledFiles(spoolDir); len(files) != 2 {
+		t.Errorf("expected both entries kept, got %v", files)
+	}
+}
+
+func TestDrainSpoolDropsInvalidWebhook(t *testing.T) {
+	t.Parallel()
+
+	spoolDir := t.TempDir()
+	if _, err := spoolMessage(spoolDir, "https://attacker.example.com/hook", TeamsMessage{Type: "message"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int
+	p := &TeamsPlugin{httpClient: statusSequenceClient(&calls, http.StatusOK)}
+	if delivered := p.drainSpool(context.Background(), &Config{SpoolDir: spoolDir}); delivered != 0 {
+		t.Errorf("expected no deliveries, got %d", delivered)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request to an invalid webhook, got %d calls", calls)
+	}
+	if files, _ := spooledFiles(spoolDir); len(files) != 0 {
+		t.Errorf("expected the invalid entry to be dropped, got %v", files)
+	}
+}
+
+func TestSpoolRestrictsExistingDir(t *testing.T) {
+	t.Parallel()
+
+	spoolDir := t.TempDir()
+	if err := os.Chmod(spoolDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := spoolMessage(spoolDir, "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789", TeamsMessage{Type: "message"}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(spoolDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o700 {
+		t.Errorf("expected private spool_dir, got %v", info.Mode().Perm())
+	}
+}
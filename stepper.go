@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
+)
+
+// DefaultStages are the pipeline stages rendered by the stepper when stages is unset.
+var DefaultStages = []string{"init", "build", "publish", "done"}
+
+// stageIndex returns the position of stage in stages, ignoring case, or -1.
+func stageIndex(stages []string, stage string) int {
+	for i, s := range stages {
+		if strings.EqualFold(strings.TrimSpace(s), strings.TrimSpace(stage)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildStepper renders stages as a horizontal ColumnSet with the current stage
+// highlighted. Earlier stages are marked complete and later ones are subtle.
+// A failed release highlights the current stage as the point of failure.
+func buildStepper(stages []string, current string, failed bool) (AdaptiveElement, bool) {
+	index := stageIndex(stages, current)
+	if index < 0 {
+		return AdaptiveElement{}, false
+	}
+
+	columns := make([]ColumnDefinition, 0, len(stages))
+	for i, stage := range stages {
+		step := AdaptiveElement{Type: "TextBlock", Text: stage, Size: "small", Wrap: true}
+		style := "default"
+		switch {
+		case i < index:
+			style = "good"
+			step.Text = "✓ " + stage
+		case i == index && failed:
+			style = "attention"
+			step.Weight = "bolder"
+		case i == index:
+			style = "accent"
+			step.Weight = "bolder"
+		default:
+			step.IsSubtle = true
+		}
+		columns = append(columns, ColumnDefinition{
+			Type:  "Column",
+			Width: "stretch",
+			Items: []AdaptiveElement{{Type: "Container", Style: style, Items: []AdaptiveElement{step}}},
+		})
+	}
+
+	return AdaptiveElement{Type: "ColumnSet", Spacing: "medium", Columns: columns}, true
+}
+
+// validateStage checks that stage names one of the configured stages.
+func validateStage(vb *helpers.ValidationBuilder, stage string, stages []string) {
+	if strings.TrimSpace(stage) == "" {
+		return
+	}
+	if stageIndex(stages, stage) < 0 {
+		vb.AddErrorWithCode("stage",
+			fmt.Sprintf("stage must be one of: %s", strings.Join(stages, ", ")),
+			"format")
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestStepperHighlightsCurrentStage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		hook      plugin.Hook
+		wantStyle string
+	}{
+		{name: "success", hook: plugin.HookPostPublish, wantStyle: "accent"},
+		{name: "error", hook: plugin.HookOnError, wantStyle: "attention"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: tt.hook,
+				Config: map[string]any{
+					"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"stage":       "Build",
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0", Branch: "main"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			card := decodeCard(t, bodies[0])
+			stepper := card.Body[1]
+			if stepper.Type != "ColumnSet" || len(stepper.Columns) != len(DefaultStages) {
+				t.Fatalf("expected stepper with %d columns, got %+v", len(DefaultStages), stepper)
+			}
+
+			styles := make([]string, len(stepper.Columns))
+			for i, column := range stepper.Columns {
+				styles[i] = column.Items[0].Style
+			}
+			want := []string{"good", tt.wantStyle, "default", "default"}
+			for i := range want {
+				if styles[i] != want[i] {
+					t.Errorf("stage %d: expected style %q, got %q", i, want[i], styles[i])
+				}
+			}
+
+			current := stepper.Columns[1].Items[0].Items[0]
+			if current.Text != "build" || current.Weight != "bolder" {
+				t.Errorf("expected bold current stage, got %+v", current)
+			}
+			if upcoming := stepper.Columns[2].Items[0].Items[0]; !upcoming.IsSubtle {
+				t.Errorf("expected upcoming stage to be subtle, got %+v", upcoming)
+			}
+		})
+	}
+}
+
+func TestStepperOmittedWithoutStage(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := buildStepper(DefaultStages, "", false); ok {
+		t.Error("expected no stepper without a stage")
+	}
+}
+
+func TestValidateStage(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+		"stages":      []any{"build", "deploy"},
+		"stage":       "publish",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Valid {
+		t.Fatal("expected unknown stage to be invalid")
+	}
+	if resp.Errors[0].Field != "stage" || resp.Errors[0].Message != "stage must be one of: build, deploy" {
+		t.Errorf("unexpected error: %+v", resp.Errors[0])
+	}
+}
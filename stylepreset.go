@@ -0,0 +1,63 @@
+package main
+
+// Card style presets accepted by style_preset.
+const (
+	StylePresetDetailed = "detailed"
+	StylePresetStandard = "standard"
+	StylePresetCompact  = "compact"
+	StylePresetMinimal  = "minimal"
+)
+
+// stylePreset holds the display defaults a preset applies. Options set
+// explicitly in the configuration still override them.
+type stylePreset struct {
+	IncludeChangelog     bool
+	ShowSummary          bool
+	ShowInfo             bool
+	ShowActions          bool
+	ShowDeprecations     bool
+	ShowContributorCount bool
+	ShowPreviousVersion  bool
+	GroupByScope         bool
+	CategoryEmoji        bool
+}
+
+// stylePresets maps preset names to their display defaults. "standard"
+// matches the defaults of the individual options.
+var stylePresets = map[string]stylePreset{
+	StylePresetDetailed: {
+		IncludeChangelog:     true,
+		ShowSummary:          true,
+		ShowInfo:             true,
+		ShowActions:          true,
+		ShowDeprecations:     true,
+		ShowContributorCount: true,
+		ShowPreviousVersion:  true,
+		GroupByScope:         true,
+		CategoryEmoji:        true,
+	},
+	StylePresetStandard: {
+		IncludeChangelog: true,
+		ShowSummary:      true,
+		ShowInfo:         true,
+		ShowActions:      true,
+		ShowDeprecations: true,
+	},
+	StylePresetCompact: {
+		ShowSummary: true,
+		ShowInfo:    true,
+		ShowActions: true,
+	},
+	StylePresetMinimal: {
+		ShowActions: true,
+	},
+}
+
+// resolveStylePreset returns the display defaults for a preset name.
+// Unknown names fall back to "standard"; validation reports them.
+func resolveStylePreset(name string) stylePreset {
+	if preset, ok := stylePresets[name]; ok {
+		return preset
+	}
+	return stylePresets[StylePresetStandard]
+}
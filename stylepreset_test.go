@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestStylePresets(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version:         "1.2.0",
+		PreviousVersion: "1.1.0",
+		TagName:         "v1.2.0",
+		RepositoryURL:   "https://github.com/acme/api",
+		ReleaseNotes:    "Fixed the login page",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Description: "add export", Author: "Jane <jane@example.com>"}},
+		},
+	}
+
+	// sections lists what a card renders, in a fixed order
+	sections := func(card AdaptiveCard) []string {
+		var got []string
+		facts := infoFacts(card.Body)
+		if len(facts) > 0 {
+			got = append(got, "info")
+		}
+		if _, ok := facts["Upgrade:"]; ok {
+			got = append(got, "upgrade")
+		}
+		if _, ok := facts["Contributors:"]; ok {
+			got = append(got, "contributors")
+		}
+		for _, elem := range card.Body {
+			if strings.HasPrefix(elem.Text, "Changes: ") {
+				got = append(got, "summary")
+			}
+		}
+		for _, elem := range card.Body {
+			if elem.Text == "Fixed the login page" {
+				got = append(got, "changelog")
+			}
+		}
+		if len(card.Actions) > 0 {
+			got = append(got, "actions")
+		}
+		return got
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   string
+	}{
+		{name: "default", want: "info,summary,changelog,actions"},
+		{name: "standard", config: map[string]any{"style_preset": "standard"}, want: "info,summary,changelog,actions"},
+		{name: "detailed", config: map[string]any{"style_preset": "detailed"}, want: "info,upgrade,contributors,summary,changelog,actions"},
+		{name: "compact", config: map[string]any{"style_preset": "compact"}, want: "info,summary,actions"},
+		{name: "minimal", config: map[string]any{"style_preset": "Minimal"}, want: "actions"},
+		{
+			name:   "explicit_option_overrides_preset",
+			config: map[string]any{"style_preset": "minimal", "include_changelog": true, "show_actions": false},
+			want:   "changelog",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{
+				"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: releaseCtx,
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+			if got := strings.Join(sections(decodeCard(t, bodies[0])), ","); got != tt.want {
+				t.Errorf("expected sections %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateStylePreset(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for preset, wantValid := range map[string]bool{"compact": true, "DETAILED": true, "fancy": false} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":  "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"style_preset": preset,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("style_preset %q: expected Valid=%v, got %+v", preset, wantValid, resp.Errors)
+		}
+	}
+}
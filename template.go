@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// templateData is the only data exposed to templates (title_template,
+// logs_url_template, upgrade_instructions, result_message_template).
+// Keeping it to plain strings means a template can't reach anything beyond
+// these fields. Each field also has a {{name}} placeholder, listed in
+// legacyPlaceholders.
+type templateData struct {
+	Version         string
+	PreviousVersion string
+	Tag             string
+	Branch          string
+	Commit          string
+	Repository      string
+	RepositoryURL   string
+	ReleaseType     string
+}
+
+// newTemplateData returns the template fields for a release.
+func newTemplateData(releaseCtx plugin.ReleaseContext) templateData {
+	repository := releaseCtx.RepositoryName
+	if releaseCtx.RepositoryOwner != "" {
+		repository = releaseCtx.RepositoryOwner + "/" + releaseCtx.RepositoryName
+	}
+	return templateData{
+		Version:         releaseCtx.Version,
+		PreviousVersion: releaseCtx.PreviousVersion,
+		Tag:             releaseCtx.TagName,
+		Branch:          releaseCtx.Branch,
+		Commit:          releaseCtx.CommitSHA,
+		Repository:      repository,
+		RepositoryURL:   repositoryWebURL(releaseCtx.RepositoryURL),
+		ReleaseType:     normalizeReleaseType(releaseCtx.ReleaseType),
+	}
+}
+
+// samplePlaceholderContext fills every template field with a representative
+// value, for validating templates before a release exists.
+var samplePlaceholderContext = plugin.ReleaseContext{
+	Version:         "1.2.3",
+	PreviousVersion: "1.2.2",
+	TagName:         "v1.2.3",
+	Branch:          "main",
+	CommitSHA:       "0123456789abcdef0123456789abcdef01234567",
+	RepositoryOwner: "owner",
+	RepositoryName:  "repo",
+	RepositoryURL:   "https://github.com/owner/repo",
+	ReleaseType:     "minor",
+}
+
+// resultMessageData is the data exposed to result_message_template: the
+// release fields plus the notification status and number of webhooks sent to.
+type resultMessageData struct {
+	templateData
+	Status   string
+	Webhooks int
+}
+
+// newResultMessageData returns the result message fields for a sent notification.
+func newResultMessageData(status string, webhooks int, releaseCtx plugin.ReleaseContext) resultMessageData {
+	return resultMessageData{
+		templateData: newTemplateData(releaseCtx),
+		Status:       status,
+		Webhooks:     webhooks,
+	}
+}
+
+// templateFuncs are the functions available to templates. Arguments are
+// ordered so the piped value comes last, e.g. {{ .Branch | default "main" }}.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"truncate": func(n int, s string) string {
+		if n < 0 || utf8.RuneCountInString(s) <= n {
+			return s
+		}
+		return string([]rune(s)[:n])
+	},
+	"default": func(def, s string) string {
+		if strings.TrimSpace(s) == "" {
+			return def
+		}
+		return s
+	},
+	"trimPrefix": func(prefix, s string) string {
+		return strings.TrimPrefix(s, prefix)
+	},
+}
+
+// legacyPlaceholderPattern matches {{name}}-style placeholders.
+var legacyPlaceholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// legacyPlaceholders maps {{name}}-style placeholders to template fields.
+var legacyPlaceholders = map[string]string{
+	"version":          "{{.Version}}",
+	"previous_version": "{{.PreviousVersion}}",
+	"tag":              "{{.Tag}}",
+	"branch":           "{{.Branch}}",
+	"commit":           "{{.Commit}}",
+	"release_type":     "{{.ReleaseType}}",
+	"repository":       "{{.Repository}}",
+	"repository_url":   "{{.RepositoryURL}}",
+}
+
+// templateIdentifiers are the bare words text/template accepts in an
+// action: its keywords and built-in functions.
+var templateIdentifiers = map[string]bool{
+	"if": true, "else": true, "end": true, "range": true, "with": true,
+	"break": true, "continue": true, "define": true, "template": true, "block": true,
+	"nil": true, "and": true, "or": true, "not": true, "len": true, "index": true,
+	"slice": true, "print": true, "printf": true, "println": true, "call": true,
+	"html": true, "js": true, "urlquery": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true,
+}
+
+// rewriteLegacyPlaceholders rewrites known {{name}} placeholders to template
+// fields. Unknown names are quoted so they render unchanged, while keywords
+// such as {{end}} and function calls are left for text/template. Values are
+// never substituted into the template text, so release data can't inject
+// actions.
+func rewriteLegacyPlaceholders(tmpl string) string {
+	return legacyPlaceholderPattern.ReplaceAllStringFunc(tmpl, func(match string) string {
+		name := legacyPlaceholderPattern.FindStringSubmatch(match)[1]
+		if field, ok := legacyPlaceholders[name]; ok {
+			return field
+		}
+		if _, ok := templateFuncs[name]; ok || templateIdentifiers[name] {
+			return match
+		}
+		return fmt.Sprintf("{{%q}}", match)
+	})
+}
+
+// renderTemplate renders a title template with text/template, after
+// rewriting {{version}}-style placeholders.
+func renderTemplate(tmpl string, releaseCtx plugin.ReleaseContext) (string, error) {
+	return executeTemplate("title", tmpl, newTemplateData(releaseCtx))
+}
+
+// executeTemplate renders tmpl against data, after rewriting
+// {{version}}-style placeholders.
+func executeTemplate(name, tmpl string, data any) (string, error) {
+	if !strings.Contains(tmpl, "{{") {
+		return tmpl, nil
+	}
+
+	parsed, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(rewriteLegacyPlaceholders(tmpl))
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	var b strings.Builder
+	if err := parsed.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	return b.String(), nil
+}
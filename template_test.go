@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	releaseCtx := plugin.ReleaseContext{
+		Version:         "1.2.3-rc.1",
+		TagName:         "v1.2.3-rc.1",
+		Branch:          "release/1.2",
+		CommitSHA:       "abc123",
+		RepositoryOwner: "relicta-tech",
+		RepositoryName:  "relicta",
+		RepositoryURL:   "git@github.com:relicta-tech/relicta.git",
+		ReleaseType:     "Minor",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "plain_text", template: "New Release", want: "New Release"},
+		{name: "legacy_version", template: "Release {{version}}", want: "Release 1.2.3-rc.1"},
+		{name: "legacy_with_spaces", template: "Release {{ version }}", want: "Release 1.2.3-rc.1"},
+		{name: "unknown_legacy_kept", template: "{{version}} {{unknown}}", want: "1.2.3-rc.1 {{unknown}}"},
+		{name: "legacy_several", template: "{{repository}} {{tag}} ({{release_type}}) from {{branch}}", want: "relicta-tech/relicta v1.2.3-rc.1 (minor) from release/1.2"},
+		{name: "legacy_commit", template: "{{version}} ({{commit}})", want: "1.2.3-rc.1 (abc123)"},
+		{name: "legacy_previous_version_empty", template: "[{{previous_version}}]", want: "[]"},
+		{name: "legacy_repository_url", template: "{{repository_url}}/actions", want: "https://github.com/relicta-tech/relicta/actions"},
+		{name: "legacy_several_with_unknown", template: "{{ tag }} {{commit_author}} {{branch}}", want: "v1.2.3-rc.1 {{commit_author}} release/1.2"},
+		{name: "field", template: "{{ .Repository }} {{ .Tag }}", want: "relicta-tech/relicta v1.2.3-rc.1"},
+		{name: "upper", template: "{{ .Version | upper }}", want: "1.2.3-RC.1"},
+		{name: "lower", template: "{{ .Repository | lower }}", want: "relicta-tech/relicta"},
+		{name: "truncate", template: "{{ .Branch | truncate 7 }}", want: "release"},
+		{name: "truncate_short", template: "{{ .Version | truncate 50 }}", want: "1.2.3-rc.1"},
+		{name: "default_used", template: `{{ .PreviousVersion | default "first" }}`, want: "first"},
+		{name: "default_unused", template: `{{ .Branch | default "main" }}`, want: "release/1.2"},
+		{name: "trim_prefix", template: `{{ .Tag | trimPrefix "v" }}`, want: "1.2.3-rc.1"},
+		{name: "release_type", template: "{{ .ReleaseType }}", want: "minor"},
+		{name: "if_else_end", template: "{{if .Commit}}{{.Commit}}{{else}}unknown{{end}} {{version}}", want: "abc123 1.2.3-rc.1"},
+		{name: "if_else_end_empty", template: "{{if .PreviousVersion}}{{previous_version}}{{else}}first{{end}}", want: "first"},
+		{name: "with_else_end", template: "{{with .Tag}}{{.}}{{else}}none{{end}}", want: "v1.2.3-rc.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := renderTemplate(tt.template, releaseCtx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("renderTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateRejectsUnsafeTemplates(t *testing.T) {
+	t.Parallel()
+
+	for _, tmpl := range []string{
+		"{{ .Environment }}",
+		`{{ env "HOME" }}`,
+		"{{ .Version",
+	} {
+		if got, err := renderTemplate(tmpl, plugin.ReleaseContext{Version: "1.0.0"}); err == nil {
+			t.Errorf("renderTemplate(%q) = %q, expected an error", tmpl, got)
+		}
+	}
+}
+
+func TestRenderTemplateDoesNotExpandValues(t *testing.T) {
+	t.Parallel()
+
+	got, err := renderTemplate("{{ .Branch }}", plugin.ReleaseContext{Branch: "{{ .Version }}", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "{{ .Version }}" {
+		t.Errorf("expected release values to be rendered verbatim, got %q", got)
+	}
+}
+
+func TestBuildTitleFallsBackOnTemplateError(t *testing.T) {
+	t.Parallel()
+
+	logger := &captureLogger{}
+	p := &TeamsPlugin{Logger: logger}
+	if got := p.buildTitle("{{ .Missing }}", DefaultTitleMaxChars, plugin.ReleaseContext{Version: "1.0.0"}); got != "Release 1.0.0" {
+		t.Errorf("expected the default title, got %q", got)
+	}
+	if _, ok := logger.find("warn", "title_template failed to render; using the default title"); !ok {
+		t.Error("expected a warning")
+	}
+}
+
+func TestValidateTitleTemplate(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for tmpl, wantValid := range map[string]bool{
+		"Release {{version}}":           true,
+		"{{ .Version | upper }}":        true,
+		"{{ .Version | shout }}":        false,
+		"{{ if .Version }}unterminated": false,
+	} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":    "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"title_template": tmpl,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("title_template %q: expected Valid=%v, got %+v", tmpl, wantValid, resp.Errors)
+		}
+	}
+}
+
+func TestResultMessageTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		webhooks []any
+		want     string
+	}{
+		{name: "default", want: "Sent Teams success notification"},
+		{name: "legacy_placeholder", template: "Notified #releases about {{version}}", want: "Notified #releases about 1.2.3"},
+		{
+			name:     "template_fields",
+			template: "{{ .Status | upper }}: {{ .Repository }}@{{ .Tag }} to {{ .Webhooks }} webhooks",
+			webhooks: fanoutWebhooks(2),
+			want:     "SUCCESS: acme/api@v1.2.3 to 3 webhooks",
+		},
+		{name: "render_error_falls_back", template: "{{ .Missing }}", want: "Sent Teams success notification"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]any{
+				"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			}
+			if tt.template != "" {
+				config["result_message_template"] = tt.template
+			}
+			if tt.webhooks != nil {
+				config["webhook_urls"] = tt.webhooks
+			}
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:   plugin.HookPostPublish,
+				Config: config,
+				Context: plugin.ReleaseContext{
+					Version:         "1.2.3",
+					TagName:         "v1.2.3",
+					RepositoryOwner: "acme",
+					RepositoryName:  "api",
+				},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+			if resp.Message != tt.want {
+				t.Errorf("expected message %q, got %q", tt.want, resp.Message)
+			}
+		})
+	}
+}
+
+func TestResultMessageTemplateKeepsErrors(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{
+		httpClient: &MockHTTPClient{
+			DoFunc: func(*http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}, nil
+			},
+		},
+	}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url":             "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"result_message_template": "Notified #releases about {{version}}",
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success || resp.Message != "" || resp.Error == "" {
+		t.Errorf("expected a failure without the templated message, got %+v", resp)
+	}
+}
+
+func TestValidateResultMessageTemplate(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for tmpl, wantValid := range map[string]bool{
+		"Notified about {{version}}":       true,
+		"{{ .Status }} to {{ .Webhooks }}": true,
+		"{{ .Missing }}":                   false,
+	} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":             "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"result_message_template": tmpl,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("result_message_template %q: expected Valid=%v, got %+v", tmpl, wantValid, resp.Errors)
+		}
+	}
+}
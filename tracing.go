@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Span names and attribute keys recorded when a Tracer is set.
+const (
+	spanExecute = "teams.execute"
+	spanSend    = "teams.send"
+
+	attrHook     = attribute.Key("teams.hook")
+	attrDryRun   = attribute.Key("teams.dry_run")
+	attrWebhook  = attribute.Key("teams.webhook")
+	attrMethod   = attribute.Key("http.request.method")
+	attrStatus   = attribute.Key("teams.status")
+	attrAttempts = attribute.Key("teams.attempts")
+)
+
+// startSpan starts a span with the plugin's Tracer. Without one it returns
+// ctx unchanged and a no-op span, so a caller's own span is never touched.
+func (p *TeamsPlugin) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if p.Tracer == nil {
+		return ctx, noop.Span{}
+	}
+	return p.Tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endExecuteSpan records the outcome of Execute on its span.
+func endExecuteSpan(span trace.Span, resp *plugin.ExecuteResponse, err error) {
+	switch {
+	case err != nil:
+		span.SetAttributes(attrStatus.String(StatusError))
+		span.SetStatus(codes.Error, err.Error())
+	case resp != nil && !resp.Success:
+		span.SetAttributes(attrStatus.String(StatusError))
+		span.SetStatus(codes.Error, resp.Error)
+	default:
+		span.SetAttributes(attrStatus.String(StatusSuccess))
+	}
+}
+
+// endSendSpan records the attempts and outcome of a send on its span, with
+// the webhook URL redacted from err.
+func endSendSpan(span trace.Span, attempts int, err error, webhookURL string) {
+	span.SetAttributes(attrAttempts.Int(attempts))
+	if err != nil {
+		span.SetAttributes(attrStatus.String(TargetStatusFailed))
+		span.SetStatus(codes.Error, redactError(err, webhookURL))
+		return
+	}
+	span.SetAttributes(attrStatus.String(TargetStatusSent))
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// spanAttrs returns a span's attributes by key.
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	// Fail once, so the send span records a retry
+	calls := 0
+	p := &TeamsPlugin{
+		Tracer:    provider.Tracer("test"),
+		sleepFunc: func(context.Context, time.Duration) error { return nil },
+		httpClient: &MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			status := http.StatusOK
+			if calls == 1 {
+				status = http.StatusServiceUnavailable
+			}
+			return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		}},
+	}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"max_retries": 1,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("unexpected failure: %v %+v", err, resp)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected an execute and a send span, got %d", len(spans))
+	}
+	send, execute := spans[0], spans[1]
+	if send.Name() != spanSend || execute.Name() != spanExecute {
+		t.Fatalf("unexpected spans %q, %q", send.Name(), execute.Name())
+	}
+	if send.Parent().SpanID() != execute.SpanContext().SpanID() {
+		t.Error("expected the send span to be a child of the execute span")
+	}
+
+	attrs := spanAttrs(execute)
+	if got := attrs[attrHook].AsString(); got != string(plugin.HookPostPublish) {
+		t.Errorf("expected hook %q, got %q", plugin.HookPostPublish, got)
+	}
+	if attrs[attrDryRun].AsBool() {
+		t.Error("expected dry_run=false")
+	}
+	if got := attrs[attrStatus].AsString(); got != StatusSuccess {
+		t.Errorf("expected execute status %q, got %q", StatusSuccess, got)
+	}
+
+	attrs = spanAttrs(send)
+	if got := attrs[attrWebhook].AsString(); got != "https://example.webhook.office.com/[redacted]" {
+		t.Errorf("expected a redacted webhook, got %q", got)
+	}
+	if got := attrs[attrAttempts].AsInt64(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+	if got := attrs[attrStatus].AsString(); got != TargetStatusSent {
+		t.Errorf("expected send status %q, got %q", TargetStatusSent, got)
+	}
+	for _, span := range spans {
+		for _, kv := range span.Attributes() {
+			if strings.Contains(kv.Value.Emit(), "IncomingWebhook") {
+				t.Errorf("span %s leaks the webhook path in %s", span.Name(), kv.Key)
+			}
+		}
+	}
+}
+
+func TestTracingFailure(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	p := &TeamsPlugin{
+		Tracer: provider.Tracer("test"),
+		httpClient: &MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		}},
+	}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookOnError,
+		Config:  map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil || resp.Success {
+		t.Fatalf("expected a failed send, got %v %+v", err, resp)
+	}
+
+	for _, span := range recorder.Ended() {
+		if span.Status().Code != codes.Error {
+			t.Errorf("expected span %s to record an error, got %v", span.Name(), span.Status())
+		}
+		if strings.Contains(span.Status().Description, "IncomingWebhook") {
+			t.Errorf("span %s leaks the webhook path: %s", span.Name(), span.Status().Description)
+		}
+	}
+	if n := len(recorder.Ended()); n != 2 {
+		t.Errorf("expected 2 spans, got %d", n)
+	}
+}
+
+func TestTracingDryRun(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	p := &TeamsPlugin{Tracer: provider.Tracer("test")}
+	if _, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"webhook_url": "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789"},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+		DryRun:  true,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != spanExecute {
+		t.Fatalf("expected only an execute span, got %d", len(spans))
+	}
+	if !spanAttrs(spans[0])[attrDryRun].AsBool() {
+		t.Error("expected dry_run=true")
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// MaxTimeoutMS bounds connect_timeout_ms and read_timeout_ms.
+const MaxTimeoutMS = 120000
+
+// EnvHTTPSProxy is the proxy used when proxy_url is unset. It is read with
+// the other proxy variables (NO_PROXY, lowercase forms) the way net/http
+// reads them.
+const EnvHTTPSProxy = "HTTPS_PROXY"
+
+// Request timeout default and bounds, in seconds.
+const (
+	DefaultRequestTimeoutSeconds = 10
+	MinRequestTimeoutSeconds     = 1
+	MaxRequestTimeoutSeconds     = 120
+)
+
+// transportOptions customizes the HTTP client used when none is injected.
+type transportOptions struct {
+	// pinnedSHA256 is a certificate fingerprint the server chain must contain.
+	pinnedSHA256 []byte
+	// rootCAs overrides the system trust store. MinVersion stays TLS 1.3.
+	rootCAs *x509.CertPool
+	// connectTimeout bounds DNS resolution and TCP connection setup, so an
+	// unreachable host fails fast. Zero leaves it to the overall timeout.
+	connectTimeout time.Duration
+	// readTimeout bounds the wait for response headers once the request is sent.
+	// Zero leaves it to the overall timeout.
+	readTimeout time.Duration
+	// insecure accepts TLS 1.2 and redirects between loopback hosts, for
+	// insecure_local_testing.
+	insecure bool
+	// timeout bounds each request attempt, including reading the response.
+	// Zero means the 10 second default.
+	timeout time.Duration
+	// proxy routes every request through an HTTP or HTTPS proxy. Redirects
+	// are still checked by the client, so the SSRF protection applies.
+	proxy *url.URL
+	// envProxy is the environment proxy configuration, used when proxy is
+	// nil. It honors NO_PROXY.
+	envProxy *httpproxy.Config
+	// verifyHostIP rejects connections to private, loopback, link-local and
+	// unspecified addresses. The dialer checks the address it connects to,
+	// after DNS resolution, so a host can't rebind between check and use.
+	// Loopback stays reachable when insecure is set.
+	verifyHostIP bool
+}
+
+// isDefault reports whether the options match the shared default client.
+func (o transportOptions) isDefault() bool {
+	defaultTimeout := o.timeout == 0 || o.timeout == DefaultRequestTimeoutSeconds*time.Second
+	return len(o.pinnedSHA256) == 0 && o.rootCAs == nil && o.connectTimeout == 0 && o.readTimeout == 0 && !o.insecure && o.proxy == nil && o.envProxy == nil && !o.verifyHostIP && defaultTimeout
+}
+
+// newDialer returns the dialer used for connections, honoring connectTimeout
+// and verifyHostIP.
+func newDialer(opts transportOptions) *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout:   opts.connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if opts.verifyHostIP {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			return checkDialAddress(address, opts.insecure)
+		}
+	}
+	return dialer
+}
+
+// errDisallowedAddress marks a connection refused by verify_host_ip. It is
+// never retried.
+var errDisallowedAddress = errors.New("disallowed address")
+
+// checkDialAddress rejects a connection to an address webhooks must never
+// target. Loopback addresses are allowed when allowLoopback is set, for
+// insecure_local_testing.
+func checkDialAddress(address string, allowLoopback bool) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid dial address %s: %w", address, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("webhook host dialed unresolved address %s: %w", host, errDisallowedAddress)
+	}
+	if allowLoopback && ip.IsLoopback() {
+		return nil
+	}
+	if isDisallowedIP(ip) {
+		return fmt.Errorf("webhook host resolves to %w %s", errDisallowedAddress, ip)
+	}
+	return nil
+}
+
+// isDisallowedIP reports whether ip is in a range webhooks must never target.
+func isDisallowedIP(ip net.IP) bool {
+	return ip.IsPrivate() ||
+		ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified()
+}
+
+// newHTTPClient builds an HTTP client with TLS 1.3+, redirect protection and
+// SSRF prevention, applying any transport options.
+func newHTTPClient(opts transportOptions) *http.Client {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		RootCAs:    opts.rootCAs,
+	}
+	if len(opts.pinnedSHA256) > 0 {
+		tlsConfig.VerifyConnection = verifyPinnedCert(opts.pinnedSHA256)
+	}
+	redirectPolicy := checkRedirect
+	if opts.insecure {
+		// Local mock servers often only speak TLS 1.2
+		tlsConfig.MinVersion = tls.VersionTLS12
+		redirectPolicy = checkLocalRedirect
+	}
+
+	timeout := opts.timeout
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeoutSeconds * time.Second
+	}
+
+	transport := &http.Transport{
+		DialContext:           newDialer(opts).DialContext,
+		ResponseHeaderTimeout: opts.readTimeout,
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   5,
+		IdleConnTimeout:       90 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+	switch {
+	case opts.proxy != nil:
+		transport.Proxy = http.ProxyURL(opts.proxy)
+	case opts.envProxy != nil:
+		proxyFor := opts.envProxy.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFor(req.URL)
+		}
+	}
+
+	return &http.Client{
+		Timeout:       timeout,
+		CheckRedirect: redirectPolicy,
+		Transport:     transport,
+	}
+}
+
+// checkRedirect restricts redirects to a short chain of HTTPS Microsoft hosts.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	// Limit redirect chain length
+	if len(via) >= 3 {
+		return fmt.Errorf("too many redirects")
+	}
+	// Prevent redirect to non-HTTPS
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to non-HTTPS URL not allowed")
+	}
+	// Prevent redirect away from Microsoft domains (SSRF protection)
+	if !isValidMicrosoftHost(req.URL.Host) {
+		return fmt.Errorf("redirect away from Microsoft domains not allowed")
+	}
+	return nil
+}
+
+// verifyPinnedCert returns a VerifyConnection callback that accepts the
+// connection only if a certificate in the server chain matches the pin.
+// Pinning an intermediate rather than the leaf reduces rotation churn.
+func verifyPinnedCert(pin []byte) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		for _, cert := range cs.PeerCertificates {
+			sum := sha256.Sum256(cert.Raw)
+			if bytes.Equal(sum[:], pin) {
+				return nil
+			}
+		}
+		return fmt.Errorf("server certificate does not match pinned_cert_sha256")
+	}
+}
+
+// parseFingerprint decodes a hex SHA-256 fingerprint, ignoring colons and case.
+func parseFingerprint(fingerprint string) ([]byte, error) {
+	cleaned := strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", "")
+	pin, err := hex.DecodeString(cleaned)
+	if err != nil || len(pin) != sha256.Size {
+		return nil, fmt.Errorf("pinned_cert_sha256 must be a 64-character hex SHA-256 fingerprint")
+	}
+	return pin, nil
+}
+
+// loadCACertFile returns the system trust store plus the PEM certificates
+// in path, so Microsoft hosts stay trusted when no middlebox intercepts.
+func loadCACertFile(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("ca_cert_file contains no PEM certificates")
+	}
+	return pool, nil
+}
+
+// parseProxyURL parses proxy_url. The error never echoes the URL, which may
+// carry proxy credentials.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("proxy_url must be an http or https URL with a host")
+	}
+	return u, nil
+}
+
+// environmentProxy returns the proxy configured by HTTPS_PROXY, HTTP_PROXY
+// and NO_PROXY, or nil when no proxy is set.
+func environmentProxy() *httpproxy.Config {
+	env := httpproxy.FromEnvironment()
+	if env.HTTPSProxy == "" && env.HTTPProxy == "" {
+		return nil
+	}
+	return env
+}
+
+// verifyHostIPDisabledByProxy reports whether verify_host_ip is set but
+// can't apply, because sends go through proxy_url or an environment proxy.
+func verifyHostIPDisabledByProxy(cfg *Config) bool {
+	return cfg.VerifyHostIP && (cfg.ProxyURL != "" || environmentProxy() != nil)
+}
+
+// transportOptionsFor derives transport options from the config.
+func transportOptionsFor(cfg *Config) (transportOptions, error) {
+	var opts transportOptions
+	if cfg.PinnedCertSHA256 != "" {
+		pin, err := parseFingerprint(cfg.PinnedCertSHA256)
+		if err != nil {
+			return opts, err
+		}
+		opts.pinnedSHA256 = pin
+	}
+	if cfg.CACertFile != "" {
+		roots, err := loadCACertFile(cfg.CACertFile)
+		if err != nil {
+			return opts, err
+		}
+		opts.rootCAs = roots
+	}
+	if cfg.ProxyURL != "" {
+		proxy, err := parseProxyURL(cfg.ProxyURL)
+		if err != nil {
+			return opts, err
+		}
+		opts.proxy = proxy
+	} else {
+		opts.envProxy = environmentProxy()
+	}
+	opts.connectTimeout = time.Duration(cfg.ConnectTimeoutMS) * time.Millisecond
+	opts.readTimeout = time.Duration(cfg.ReadTimeoutMS) * time.Millisecond
+	opts.insecure = cfg.InsecureLocalTesting
+	opts.timeout = time.Duration(cfg.RequestTimeoutSeconds) * time.Second
+	// Through a proxy, the dialer only sees the proxy's address
+	opts.verifyHostIP = cfg.VerifyHostIP && opts.proxy == nil && opts.envProxy == nil
+	return opts, nil
+}
+
+// transportKey identifies a transport configuration by the options it is
+// derived from, so equal configs share one client.
+type transportKey struct {
+	pinnedCertSHA256 string
+	caCertFile       string
+	proxyURL         string
+	envProxy         httpproxy.Config
+	connectTimeoutMS int
+	readTimeoutMS    int
+	timeoutSeconds   int
+	insecure         bool
+	verifyHostIP     bool
+}
+
+func transportKeyFor(cfg *Config) transportKey {
+	var envProxy httpproxy.Config
+	if cfg.ProxyURL == "" {
+		if env := environmentProxy(); env != nil {
+			envProxy = *env
+		}
+	}
+	return transportKey{
+		pinnedCertSHA256: cfg.PinnedCertSHA256,
+		caCertFile:       cfg.CACertFile,
+		proxyURL:         cfg.ProxyURL,
+		envProxy:         envProxy,
+		connectTimeoutMS: cfg.ConnectTimeoutMS,
+		readTimeoutMS:    cfg.ReadTimeoutMS,
+		timeoutSeconds:   cfg.RequestTimeoutSeconds,
+		insecure:         cfg.InsecureLocalTesting,
+		verifyHostIP:     cfg.VerifyHostIP,
+	}
+}
+
+// clientCache holds one HTTP client per transport configuration, so sends
+// reuse keep-alive connections and ca_cert_file is read once.
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[transportKey]HTTPClient
+}
+
+func newClientCache() *clientCache {
+	return &clientCache{clients: make(map[transportKey]HTTPClient)}
+}
+
+// defaultClientCache is shared by plugin instances without their own cache.
+var defaultClientCache = newClientCache()
+
+// httpClientFor returns the HTTP client for a send. Injected clients are used
+// as-is; otherwise the shared default is used unless the config needs a
+// customized transport, which is built once per plugin and reused. The
+// default client is never cached, so replacing defaultHTTPClient takes effect
+// on the next send.
+func (p *TeamsPlugin) httpClientFor(cfg *Config) (HTTPClient, error) {
+	if p.httpClient != nil {
+		return p.httpClient, nil
+	}
+	cache := p.clients
+	if cache == nil {
+		cache = defaultClientCache
+	}
+
+	key := transportKeyFor(cfg)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if client, ok := cache.clients[key]; ok {
+		return client, nil
+	}
+
+	opts, err := transportOptionsFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.VerifyHostIP && !opts.verifyHostIP {
+		p.getLogger().Warn("verify_host_ip is off: sends go through a proxy, which connects to the webhook host")
+	}
+	if opts.isDefault() {
+		return defaultHTTPClient, nil
+	}
+	client := newHTTPClient(opts)
+	cache.clients[key] = client
+	return client, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"html"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// upgradeInstructionsHeading titles the upgrade_instructions section.
+const upgradeInstructionsHeading = "📦 How to upgrade"
+
+// renderUpgradeInstructions expands the upgrade_instructions template for a
+// release. The result is escaped or sanitized like release notes.
+func renderUpgradeInstructions(tmpl string, releaseCtx plugin.ReleaseContext, markdown bool) (string, error) {
+	text, err := executeTemplate("upgrade_instructions", tmpl, newTemplateData(releaseCtx))
+	if err != nil {
+		return "", err
+	}
+	text = strings.TrimSpace(text)
+	if markdown {
+		return sanitizeMarkdown(text), nil
+	}
+	return html.EscapeString(text), nil
+}
+
+// buildUpgradeSection renders upgrade instructions under their own heading.
+func buildUpgradeSection(instructions string) AdaptiveElement {
+	return AdaptiveElement{
+		Type:      "Container",
+		Separator: true,
+		Spacing:   "medium",
+		Items: []AdaptiveElement{
+			{Type: "TextBlock", Text: upgradeInstructionsHeading, Weight: "bolder"},
+			{Type: "TextBlock", Text: instructions, Wrap: true},
+		},
+	}
+}
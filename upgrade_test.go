@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestUpgradeInstructions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		instructions string
+		markdown     bool
+		want         string
+	}{
+		{name: "omitted_by_default"},
+		{
+			name:         "legacy_placeholder",
+			instructions: "Run `npm install acme@{{version}}`",
+			want:         "Run `npm install acme@1.2.0`",
+		},
+		{
+			name:         "go_template",
+			instructions: "Pin **{{ .Tag }}** on {{ .Branch | default \"main\" }}",
+			want:         "Pin **v1.2.0** on main",
+		},
+		{
+			name:         "escaped",
+			instructions: "Use <b>{{version}}</b>",
+			want:         "Use &lt;b&gt;1.2.0&lt;/b&gt;",
+		},
+		{
+			name:         "markdown",
+			instructions: "Use <b>{{version}}</b>, see [docs](https://acme.dev) & [x](javascript:alert(1))",
+			markdown:     true,
+			want:         "Use 1.2.0, see [docs](https://acme.dev) & x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies [][]byte
+			p := &TeamsPlugin{httpClient: recordingClient(&bodies)}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"webhook_url":          "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+					"upgrade_instructions": tt.instructions,
+					"render_markdown":      tt.markdown,
+				},
+				Context: plugin.ReleaseContext{Version: "1.2.0", TagName: "v1.2.0"},
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("unexpected failure: %v %+v", err, resp)
+			}
+
+			var section *AdaptiveElement
+			for _, elem := range decodeCard(t, bodies[0]).Body {
+				if len(elem.Items) == 2 && elem.Items[0].Text == upgradeInstructionsHeading {
+					section = &elem
+				}
+			}
+			if tt.want == "" {
+				if section != nil {
+					t.Errorf("expected no upgrade section, got %+v", section)
+				}
+				return
+			}
+			if section == nil {
+				t.Fatal("expected an upgrade section")
+			}
+			if got := section.Items[1].Text; got != tt.want {
+				t.Errorf("expected instructions %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateUpgradeInstructions(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	for instructions, wantValid := range map[string]bool{
+		"Run `go get acme@{{ .Tag }}`": true,
+		"Run {{ .Missing }}":           false,
+		"Run {{ .Tag":                  false,
+	} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"webhook_url":          "https://example.webhook.office.com/webhookb2/123/IncomingWebhook/456/789",
+			"upgrade_instructions": instructions,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != wantValid {
+			t.Errorf("upgrade_instructions %q: expected Valid=%v, got %+v", instructions, wantValid, resp.Errors)
+		}
+	}
+}
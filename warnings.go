@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// warningPrefix marks soft findings in the validation response.
+const warningPrefix = "warning: "
+
+// validationWarnings collects soft findings that are reported alongside
+// validation errors but never make the configuration invalid.
+type validationWarnings []plugin.ValidationError
+
+// add records a warning.
+func (w *validationWarnings) add(field, message, code string) {
+	*w = append(*w, plugin.ValidationError{
+		Field:   field,
+		Message: warningPrefix + message,
+		Code:    code,
+	})
+}
+
+// apply appends the warnings to the response without changing Valid.
+func (w validationWarnings) apply(resp *plugin.ValidateResponse) *plugin.ValidateResponse {
+	resp.Errors = append(resp.Errors, w...)
+	return resp
+}
+
+// isWarning reports whether a validation entry is a warning.
+func isWarning(e plugin.ValidationError) bool {
+	return strings.HasPrefix(e.Message, warningPrefix)
+}
+
+// expectedWebhookPathSegments lists path segments every webhook URL on a host
+// suffix contains. Missing segments usually mean a truncated copy-paste.
+var expectedWebhookPathSegments = []struct {
+	hostSuffix string
+	segments   []string
+}{
+	{hostSuffix: ".webhook.office.com", segments: []string{"webhookb2", "IncomingWebhook"}},
+	{hostSuffix: ".logic.azure.com", segments: []string{"workflows", "triggers"}},
+}
+
+// webhookPathWarning describes a suspicious webhook URL path, or returns "".
+func webhookPathWarning(webhookURL string) string {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return ""
+	}
+
+	for _, expected := range expectedWebhookPathSegments {
+		if !strings.HasSuffix(parsed.Hostname(), expected.hostSuffix) {
+			continue
+		}
+		segments := strings.Split(parsed.Path, "/")
+		for _, want := range expected.segments {
+			if !containsFold(segments, want) {
+				return fmt.Sprintf("webhook URL path is missing the %q segment expected for %s URLs; check it was copied completely",
+					want, strings.TrimPrefix(expected.hostSuffix, "."))
+			}
+		}
+	}
+	return ""
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWebhookPathWarning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		url         string
+		wantSegment string
+	}{
+		{
+			name: "valid_connector_path",
+			url:  "https://example.webhook.office.com/webhookb2/abc@def/IncomingWebhook/123/456",
+		},
+		{
+			name: "valid_workflows_path",
+			url:  "https://prod-00.westus.logic.azure.com:443/workflows/abc/triggers/manual/paths/invoke",
+		},
+		{
+			name:        "truncated_connector_path",
+			url:         "https://example.webhook.office.com/webhookb2/abc@def",
+			wantSegment: `"IncomingWebhook"`,
+		},
+		{
+			name:        "connector_missing_prefix",
+			url:         "https://example.webhook.office.com/IncomingWebhook/123",
+			wantSegment: `"webhookb2"`,
+		},
+		{
+			name:        "workflows_missing_trigger",
+			url:         "https://prod-00.westus.logic.azure.com/workflows/abc",
+			wantSegment: `"triggers"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := webhookPathWarning(tt.url)
+			if tt.wantSegment == "" {
+				if got != "" {
+					t.Errorf("expected no warning, got %q", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.wantSegment) {
+				t.Errorf("expected warning naming %s, got %q", tt.wantSegment, got)
+			}
+		})
+	}
+}
+
+func TestValidateSuspiciousWebhookPath(t *testing.T) {
+	t.Parallel()
+
+	p := &TeamsPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"webhook_url": "https://example.webhook.office.com/webhookb2/abc@def",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.Valid {
+		t.Error("a suspicious path must not make the config invalid")
+	}
+	if len(resp.Errors) != 1 {
+		t.Fatalf("expected one warning, got %+v", resp.Errors)
+	}
+	warning := resp.Errors[0]
+	if !isWarning(warning) || warning.Field != "webhook_url" || warning.Code != "format" {
+		t.Errorf("unexpected warning: %+v", warning)
+	}
+}